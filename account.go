@@ -21,13 +21,38 @@ type Account struct {
 	Finalized          bool   `json:"finalized,omitempty"`
 	SignupVia          string `json:"signup_via,omitempty"`
 	SupportTrialStatus string `json:"support_trial_status,omitempty"`
-	Cancellation       string `json:cancellation,omitempty`
+	Cancellation       string `json:"cancellation,omitempty"`
 	CanceledAt         string `json:"canceled_at,omitempty"`
 	CancelledAt        string `json:"cancelled_at,omitempty"`
 	CreatedAt          string `json:"created_at,omitempty"`
 	UpdatedAt          string `json:"updated_at,omitempty"`
 }
 
+// Canceled returns true if the account has been canceled, and false
+// otherwise.
+func (account *Account) Canceled() bool {
+	return len(account.CanceledAt) > 0 || len(account.CancelledAt) > 0
+}
+
+// AccountUpdate is a data structure that describes changes to several fields
+// of an Account at once. Only the fields that are set are changed, so a field
+// can be cleared by setting it to point at an empty string.
+type AccountUpdate struct {
+	Name             *string `json:"name,omitempty"`
+	Plan             *string `json:"plan,omitempty"`
+	SupportPlan      *string `json:"support_plan,omitempty"`
+	EmergencyContact *string `json:"emergency_contact,omitempty"`
+}
+
+// Empty returns true if the update doesn't change anything, and false
+// otherwise.
+func (update *AccountUpdate) Empty() bool {
+	return update.Name == nil &&
+		update.Plan == nil &&
+		update.SupportPlan == nil &&
+		update.EmergencyContact == nil
+}
+
 // AccountService is a repository one can use to retrieve and save Account
 // records on the API.
 type AccountService struct {
@@ -48,21 +73,119 @@ func (service *AccountService) All(params Params) []*Account {
 // Find returns the Account record identified by the given account id. If there
 // are errors in retrieving this information, an error is returned as well.
 func (service *AccountService) Find(id string) (*Account, error) {
-	response := service.Driver.Get("accounts/"+id, nil)
-	if response.Okay() {
-		wrapper := struct {
-			Account *Account `json:"account,omitempty"`
-		}{}
+	return service.unwrap(service.Driver.Get("accounts/"+id, nil))
+}
 
-		err := json.Unmarshal(response.Pages[0], &wrapper)
-		if err != nil {
-			return nil, err
-		}
+type accountParams struct {
+	Name             string `json:"name,omitempty"`
+	Plan             string `json:"plan,omitempty"`
+	SupportPlan      string `json:"support_plan,omitempty"`
+	Type             string `json:"type,omitempty"`
+	EmergencyContact string `json:"emergency_contact,omitempty"`
+}
+
+// Create takes an Account and saves it on the upstream API. The Account must
+// have a Name. If there are issues along the way, an error is returned.
+// Otherwise, the newly created Account is returned.
+func (service *AccountService) Create(account *Account) (*Account, error) {
+	if account == nil || len(account.Name) == 0 {
+		return nil, fmt.Errorf("An account requires a name")
+	}
+
+	wrapper := struct {
+		Account *accountParams `json:"account,omitempty"`
+	}{
+		Account: &accountParams{
+			Name:             account.Name,
+			Plan:             account.Plan,
+			SupportPlan:      account.SupportPlan,
+			Type:             account.Type,
+			EmergencyContact: account.EmergencyContact,
+		},
+	}
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(service.Driver.Post("accounts", nil, body))
+}
+
+// Update takes an Account and an AccountUpdate, saving all of the changes
+// described by the AccountUpdate on the upstream API in a single request. If
+// there are issues along the way, an error is returned. Otherwise, the
+// updated Account is returned.
+func (service *AccountService) Update(account *Account, changes *AccountUpdate) (*Account, error) {
+	if account == nil || len(account.ID) == 0 {
+		return nil, fmt.Errorf("No valid account given")
+	}
+
+	if changes == nil || changes.Empty() {
+		return nil, fmt.Errorf("No account changes given")
+	}
+
+	wrapper := struct {
+		Account *AccountUpdate `json:"account,omitempty"`
+	}{Account: changes}
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.update(account, body)
+}
+
+// Cancel takes an Account and the reason for canceling it, then cancels the
+// Account on the upstream API. If there are issues along the way, an error is
+// returned. Otherwise, the canceled Account is returned.
+func (service *AccountService) Cancel(account *Account, reason string) (*Account, error) {
+	if account == nil || len(account.ID) == 0 {
+		return nil, fmt.Errorf("can't cancel an account without an ID")
+	}
 
-		return wrapper.Account, nil
+	if account.Canceled() {
+		return nil, fmt.Errorf("Account %s is already canceled", account.Name)
 	}
 
-	return nil, response.Error
+	if len(reason) == 0 {
+		return nil, fmt.Errorf("A cancellation requires a reason")
+	}
+
+	wrapper := struct {
+		Cancellation struct {
+			Reason string `json:"reason"`
+		} `json:"cancellation"`
+	}{}
+	wrapper.Cancellation.Reason = reason
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Post("accounts/"+account.ID+"/cancellation", nil, body),
+	)
+}
+
+// Reactivate takes a canceled Account and reactivates it on the upstream API.
+// The API only allows this for some accounts, so the request may be refused.
+// If there are issues along the way, an error is returned. Otherwise, the
+// reactivated Account is returned.
+func (service *AccountService) Reactivate(account *Account) (*Account, error) {
+	if account == nil || len(account.ID) == 0 {
+		return nil, fmt.Errorf("can't reactivate an account without an ID")
+	}
+
+	if !account.Canceled() {
+		return nil, fmt.Errorf("Account %s is not canceled", account.Name)
+	}
+
+	return service.unwrap(
+		service.Driver.Delete("accounts/"+account.ID+"/cancellation", Params{}),
+	)
 }
 
 // ForUser returns an array of Accounts that are both associated with the
@@ -120,7 +243,7 @@ type accountSupportPlan struct {
 // the way, an error is returned. Otherwise, the updated Account is returned.
 func (service *AccountService) UpdateSupportPlan(account *Account, plan string) (*Account, error) {
 	wrapper := struct {
-		Account *accountSupportPlan `json:"account, omitempty"`
+		Account *accountSupportPlan `json:"account,omitempty"`
 	}{&accountSupportPlan{SupportPlan: plan}}
 
 	body, err := json.Marshal(&wrapper)
@@ -136,21 +259,24 @@ func (service *AccountService) update(account *Account, data []byte) (*Account,
 		return nil, fmt.Errorf("can't update an account without an ID")
 	}
 
-	response := service.Driver.Put("accounts/"+account.ID, nil, data)
-	if response.Okay() {
-		wrapped := struct {
-			Account *Account `json:"account,omitempty"`
-		}{}
+	return service.unwrap(service.Driver.Put("accounts/"+account.ID, nil, data))
+}
 
-		err := json.Unmarshal(response.Pages[0], &wrapped)
-		if err != nil {
-			return nil, err
-		}
+func (service *AccountService) unwrap(response Response) (*Account, error) {
+	if !response.Okay() {
+		return nil, response.Error
+	}
 
-		return wrapped.Account, nil
+	wrapper := struct {
+		Account *Account `json:"account,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, response.Error
+	return wrapper.Account, nil
 }
 
 func (service *AccountService) collection(path string, params Params) []*Account {
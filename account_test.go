@@ -368,6 +368,199 @@ func TestAccountService_UpdateSupportPlan(t *testing.T) {
 	})
 }
 
+func TestAccountService_Create(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAccountService(driver)
+
+	t.Run("without a name", func(t *testing.T) {
+		if _, err := service.Create(&Account{Plan: "standard"}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when the creation is successful", func(t *testing.T) {
+		driver.AddResponse(
+			"post",
+			"accounts",
+			Response{Pages: [][]byte{[]byte(`{"account": {"id": "9", "name": "New Account"}}`)}},
+		)
+
+		result, err := service.Create(&Account{ID: "ignored", Name: "New Account", Plan: "standard"})
+
+		t.Run("it sends the account settings", func(t *testing.T) {
+			bodies := driver.Bodies("post")
+			expected := `{"account":{"name":"New Account","plan":"standard"}}`
+
+			if len(bodies) != 1 || string(bodies[0]) != expected {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns the new account", func(t *testing.T) {
+			if result == nil || result.ID != "9" {
+				t.Errorf("Expected account 9, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestAccountService_Update(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAccountService(driver)
+	original := &Account{ID: "1", Name: "Account 1"}
+
+	t.Run("without a valid account", func(t *testing.T) {
+		name := "Account 1 New"
+
+		for _, invalid := range []*Account{nil, {}} {
+			if _, err := service.Update(invalid, &AccountUpdate{Name: &name}); err == nil {
+				t.Errorf("Expected an error")
+			}
+		}
+
+		if len(driver.Requests("put")) != 0 {
+			t.Errorf("Expected no put requests")
+		}
+	})
+
+	t.Run("without any changes", func(t *testing.T) {
+		if _, err := service.Update(original, &AccountUpdate{}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with several changes", func(t *testing.T) {
+		name := "Account 1 New"
+		contact := ""
+
+		driver.AddResponse(
+			"put",
+			"accounts/1",
+			Response{Pages: [][]byte{[]byte(`{"account": {"id": "1", "name": "Account 1 New"}}`)}},
+		)
+
+		result, err := service.Update(original, &AccountUpdate{Name: &name, EmergencyContact: &contact})
+
+		t.Run("it sends every change in one request", func(t *testing.T) {
+			bodies := driver.Bodies("put")
+			expected := `{"account":{"name":"Account 1 New","emergency_contact":""}}`
+
+			if len(bodies) != 1 || string(bodies[0]) != expected {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns the updated account", func(t *testing.T) {
+			if result == nil || result.Name != name {
+				t.Errorf("Expected the updated account, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestAccountService_Cancel(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAccountService(driver)
+	account := &Account{ID: "1", Name: "Account 1"}
+
+	t.Run("without a reason", func(t *testing.T) {
+		if _, err := service.Cancel(account, ""); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("for an account that is already canceled", func(t *testing.T) {
+		if _, err := service.Cancel(&Account{ID: "1", CanceledAt: "2018-06-01T00:00:00Z"}, "done"); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when the cancellation is successful", func(t *testing.T) {
+		driver.AddResponse(
+			"post",
+			"accounts/1/cancellation",
+			Response{Pages: [][]byte{[]byte(`{"account": {"id": "1", "cancelled_at": "2018-06-15T00:00:00Z"}}`)}},
+		)
+
+		result, err := service.Cancel(account, "Moving on")
+
+		t.Run("it sends the reason", func(t *testing.T) {
+			bodies := driver.Bodies("post")
+
+			if len(bodies) != 1 || string(bodies[0]) != `{"cancellation":{"reason":"Moving on"}}` {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns the canceled account", func(t *testing.T) {
+			if result == nil || !result.Canceled() {
+				t.Errorf("Expected a canceled account, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestAccountService_Reactivate(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAccountService(driver)
+	canceled := &Account{ID: "1", CancelledAt: "2018-06-15T00:00:00Z"}
+
+	t.Run("for an active account", func(t *testing.T) {
+		if _, err := service.Reactivate(&Account{ID: "1"}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when the API allows the reactivation", func(t *testing.T) {
+		driver.AddResponse(
+			"delete",
+			"accounts/1/cancellation",
+			Response{Pages: [][]byte{[]byte(`{"account": {"id": "1"}}`)}},
+		)
+
+		result, err := service.Reactivate(canceled)
+
+		t.Run("it returns the active account", func(t *testing.T) {
+			if result == nil || result.Canceled() {
+				t.Errorf("Expected an active account, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when the API refuses the reactivation", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "accounts/1/cancellation", Response{Error: fmt.Errorf("Oh no!")})
+
+		if _, err := service.Reactivate(canceled); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
 func stubAccounts(driver *MockDriver, accounts ...*Account) {
 	pages := make([][]byte, 0)
 
@@ -2,17 +2,72 @@ package eygo
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 // Addon is a data structure that models a platform addon on the Engine
 // Yard API.
 type Addon struct {
-	ID     int               `json:"id,omitempty"`
-	Name   string            `json:"name,omitempty"`
-	SSOURL string            `json:"sso_url,omitempty"`
-	Vars   map[string]string `json:"vars,omitempty"`
+	ID     int       `json:"id,omitempty"`
+	Name   string    `json:"name,omitempty"`
+	SSOURL string    `json:"sso_url,omitempty"`
+	Vars   AddonVars `json:"vars,omitempty"`
+}
+
+// AddonVars is a map of the configuration variables for an Addon. Its values
+// are masked whenever it is formatted for printing or logging, but they are
+// still available by key and are sent to the API as-is.
+type AddonVars map[string]string
+
+const maskedValue = "********"
+
+// String returns a representation of the variables in which every value is
+// masked.
+func (vars AddonVars) String() string {
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	masked := make([]string, 0, len(keys))
+	for _, key := range keys {
+		masked = append(masked, key+":"+maskedValue)
+	}
+
+	return "map[" + strings.Join(masked, " ") + "]"
 }
 
+// GoString returns a Go-syntax representation of the variables in which every
+// value is masked.
+func (vars AddonVars) GoString() string {
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	masked := make([]string, 0, len(keys))
+	for _, key := range keys {
+		masked = append(masked, strconv.Quote(key)+":"+strconv.Quote(maskedValue))
+	}
+
+	return "eygo.AddonVars{" + strings.Join(masked, ", ") + "}"
+}
+
+const (
+	// MergeVars is an UpdateVars mode in which the given variables are added
+	// to the addon's existing variables, replacing any with the same key.
+	MergeVars = "merge"
+
+	// ReplaceVars is an UpdateVars mode in which the given variables replace
+	// all of the addon's existing variables.
+	ReplaceVars = "replace"
+)
+
 // AddonService is a repository one can use to retrieve, enable, and disable
 // Addon records on the API.
 type AddonService struct {
@@ -31,6 +86,169 @@ func (service *AddonService) ForAccount(account *Account, params Params) []*Addo
 	return service.collection("accounts/"+account.ID+"/addons", params)
 }
 
+// ForEnvironment returns an array of Addons that are both associated with the
+// given Environment and matching the given Params.
+func (service *AddonService) ForEnvironment(environment *Environment, params Params) []*Addon {
+	return service.collection(
+		fmt.Sprintf("environments/%d/addons", environment.ID),
+		params,
+	)
+}
+
+// Find returns the Addon record identified by the given addon id on the given
+// Account. If there are errors in retrieving this information, an error is
+// returned as well.
+func (service *AddonService) Find(account *Account, id string) (*Addon, error) {
+	if account == nil || len(account.ID) == 0 {
+		return nil, fmt.Errorf("No valid account given")
+	}
+
+	return service.unwrap(
+		service.Driver.Get("accounts/"+account.ID+"/addons/"+id, nil),
+	)
+}
+
+type addonParams struct {
+	Name   string            `json:"name,omitempty"`
+	SSOURL string            `json:"sso_url,omitempty"`
+	Vars   map[string]string `json:"vars"`
+}
+
+// Create takes an Account and an Addon, saving the Addon on the upstream API
+// under the given Account. If there are issues along the way, an error is
+// returned. Otherwise, the newly created Addon is returned.
+func (service *AddonService) Create(account *Account, addon *Addon) (*Addon, error) {
+	if account == nil || len(account.ID) == 0 {
+		return nil, fmt.Errorf("No valid account given")
+	}
+
+	if addon == nil || len(addon.Name) == 0 {
+		return nil, fmt.Errorf("An addon requires a name")
+	}
+
+	vars := addon.Vars
+	if vars == nil {
+		vars = AddonVars{}
+	}
+
+	body, err := service.encode(
+		&addonParams{Name: addon.Name, SSOURL: addon.SSOURL, Vars: vars},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Post("accounts/"+account.ID+"/addons", nil, body),
+	)
+}
+
+// UpdateVars takes an Account, an Addon, a map of variables, and a mode
+// (either MergeVars or ReplaceVars), then saves the Addon's variables on the
+// upstream API. When merging, the Addon's current variables are retrieved
+// from the API first, and nothing is saved if they can't be read. If there are
+// issues along the way, an error is returned. Otherwise, the updated Addon is
+// returned.
+func (service *AddonService) UpdateVars(account *Account, addon *Addon, vars map[string]string, mode string) (*Addon, error) {
+	if account == nil || len(account.ID) == 0 {
+		return nil, fmt.Errorf("No valid account given")
+	}
+
+	if addon == nil || addon.ID == 0 {
+		return nil, fmt.Errorf("can't update an addon without an ID")
+	}
+
+	desired := make(map[string]string)
+
+	switch mode {
+	case MergeVars:
+		current, err := service.Find(account, strconv.Itoa(addon.ID))
+		if err != nil {
+			return nil, err
+		}
+
+		if current == nil {
+			return nil, fmt.Errorf("Couldn't find addon %d", addon.ID)
+		}
+
+		if current.Vars == nil {
+			return nil, fmt.Errorf("Couldn't read the current vars of addon %d", addon.ID)
+		}
+
+		for key, value := range current.Vars {
+			desired[key] = value
+		}
+	case ReplaceVars:
+	default:
+		return nil, fmt.Errorf("Unknown vars update mode: %s", mode)
+	}
+
+	for key, value := range vars {
+		desired[key] = value
+	}
+
+	body, err := service.encode(&addonParams{Vars: desired})
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Put(
+			fmt.Sprintf("accounts/%s/addons/%d", account.ID, addon.ID),
+			nil,
+			body,
+		),
+	)
+}
+
+// Destroy deletes the given Addon from the given Account on the upstream API.
+// If there are issues along the way, an error is returned.
+func (service *AddonService) Destroy(account *Account, addon *Addon) error {
+	if account == nil || len(account.ID) == 0 {
+		return fmt.Errorf("No valid account given")
+	}
+
+	if addon == nil || addon.ID == 0 {
+		return fmt.Errorf("No valid addon given")
+	}
+
+	response := service.Driver.Delete(
+		fmt.Sprintf("accounts/%s/addons/%d", account.ID, addon.ID),
+		Params{},
+	)
+
+	if !response.Okay() {
+		return response.Error
+	}
+
+	return nil
+}
+
+func (service *AddonService) encode(params *addonParams) ([]byte, error) {
+	wrapper := struct {
+		Addon *addonParams `json:"addon,omitempty"`
+	}{Addon: params}
+
+	return json.Marshal(&wrapper)
+}
+
+func (service *AddonService) unwrap(response Response) (*Addon, error) {
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		Addon *Addon `json:"addon,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Addon, nil
+}
+
 func (service *AddonService) collection(path string, params Params) []*Addon {
 	addons := make([]*Addon, 0)
 	response := service.Driver.Get(path, params)
@@ -2,6 +2,10 @@ package eygo
 
 import (
 	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -69,6 +73,333 @@ func TestAddonService_ForAccount(t *testing.T) {
 
 }
 
+func TestAddonService_ForEnvironment(t *testing.T) {
+	environment := &Environment{ID: 1, Name: "Environment 1"}
+	driver := NewMockDriver()
+	service := NewAddonService(driver)
+
+	t.Run("when there are matching addons", func(t *testing.T) {
+		stubEnvironmentAddons(driver, environment, &Addon{ID: 1}, &Addon{ID: 2})
+
+		all := service.ForEnvironment(environment, nil)
+
+		t.Run("it contains all matching addons", func(t *testing.T) {
+			if len(all) != 2 || all[0].ID != 1 || all[1].ID != 2 {
+				t.Errorf("Expected addons 1 and 2, got %v", all)
+			}
+		})
+	})
+
+	t.Run("when there are no matching addons", func(t *testing.T) {
+		driver.Reset()
+
+		t.Run("it is empty", func(t *testing.T) {
+			if all := service.ForEnvironment(environment, nil); len(all) != 0 {
+				t.Errorf("Expected 0 addons, got %d", len(all))
+			}
+		})
+	})
+}
+
+func TestAddonService_Find(t *testing.T) {
+	account := &Account{ID: "1"}
+	driver := NewMockDriver()
+	service := NewAddonService(driver)
+	addon := &Addon{ID: 1, Name: "Addon 1"}
+	stubAddon(driver, account, addon)
+
+	t.Run("for a known addon", func(t *testing.T) {
+		result, err := service.Find(account, "1")
+
+		t.Run("it is the requested addon", func(t *testing.T) {
+			if result.ID != addon.ID {
+				t.Errorf("Expected addon 1, got addon %d", result.ID)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("for an unknown addon", func(t *testing.T) {
+		result, err := service.Find(account, "2")
+
+		t.Run("it returns no addon", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no addon, got addon %d", result.ID)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestAddonService_Create(t *testing.T) {
+	account := &Account{ID: "1"}
+	driver := NewMockDriver()
+	service := NewAddonService(driver)
+
+	t.Run("without a name", func(t *testing.T) {
+		_, err := service.Create(account, &Addon{})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when the creation is successful", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse(
+			"post",
+			"accounts/1/addons",
+			Response{Pages: [][]byte{[]byte(`{"addon": {"id": 3, "name": "logs", "vars": {"TOKEN": "abc"}}}`)}},
+		)
+
+		result, err := service.Create(account, &Addon{Name: "logs", Vars: AddonVars{"TOKEN": "abc"}})
+
+		t.Run("it returns the new addon", func(t *testing.T) {
+			if result == nil || result.ID != 3 || result.Vars["TOKEN"] != "abc" {
+				t.Errorf("Expected addon 3 with its vars, got %v", result)
+			}
+		})
+
+		t.Run("it sends the real variable values", func(t *testing.T) {
+			bodies := driver.Bodies("post")
+
+			if len(bodies) != 1 || !strings.Contains(string(bodies[0]), `"TOKEN":"abc"`) {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when the creation fails", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("post", "accounts/1/addons", Response{Error: fmt.Errorf("Oh no!")})
+
+		_, err := service.Create(account, &Addon{Name: "logs"})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestAddonService_UpdateVars(t *testing.T) {
+	account := &Account{ID: "1"}
+	driver := NewMockDriver()
+	service := NewAddonService(driver)
+	addon := &Addon{ID: 3, Name: "logs"}
+	updated := Response{Pages: [][]byte{[]byte(`{"addon": {"id": 3, "name": "logs"}}`)}}
+
+	sent := func() map[string]string {
+		wrapper := struct {
+			Addon struct {
+				Vars map[string]string `json:"vars"`
+			} `json:"addon"`
+		}{}
+
+		bodies := driver.Bodies("put")
+		if len(bodies) != 1 {
+			t.Fatalf("Expected 1 put request, got %d", len(bodies))
+		}
+
+		json.Unmarshal(bodies[0], &wrapper)
+
+		return wrapper.Addon.Vars
+	}
+
+	t.Run("with an unknown mode", func(t *testing.T) {
+		driver.Reset()
+
+		_, err := service.UpdateVars(account, addon, map[string]string{"A": "1"}, "sideways")
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when merging", func(t *testing.T) {
+		driver.Reset()
+		stubAddon(driver, account, &Addon{ID: 3, Vars: AddonVars{"A": "old", "B": "kept"}})
+		driver.AddResponse("put", "accounts/1/addons/3", updated)
+
+		_, err := service.UpdateVars(account, addon, map[string]string{"A": "new", "C": "added"}, MergeVars)
+
+		t.Run("it sends the existing variables with the changes", func(t *testing.T) {
+			vars := sent()
+
+			if len(vars) != 3 || vars["A"] != "new" || vars["B"] != "kept" || vars["C"] != "added" {
+				t.Errorf("Unexpected vars: %v", vars)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when merging with an unknown addon", func(t *testing.T) {
+		driver.Reset()
+
+		_, err := service.UpdateVars(account, addon, map[string]string{"A": "new"}, MergeVars)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when merging and the addon is missing from the response", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("get", "accounts/1/addons/3", Response{Pages: [][]byte{[]byte(`{}`)}})
+
+		_, err := service.UpdateVars(account, addon, map[string]string{"A": "new"}, MergeVars)
+
+		t.Run("it returns an error without saving", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+
+			if len(driver.Requests("put")) != 0 {
+				t.Errorf("Expected no put requests")
+			}
+		})
+	})
+
+	t.Run("when merging and the current vars can't be read", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("get", "accounts/1/addons/3", Response{Pages: [][]byte{[]byte(`{"addon": {"id": 3}}`)}})
+
+		_, err := service.UpdateVars(account, addon, map[string]string{"A": "new"}, MergeVars)
+
+		t.Run("it doesn't replace the vars", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+
+			if len(driver.Requests("put")) != 0 {
+				t.Errorf("Expected no put requests")
+			}
+		})
+	})
+
+	t.Run("when replacing", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("put", "accounts/1/addons/3", updated)
+
+		_, err := service.UpdateVars(account, addon, map[string]string{"C": "only"}, ReplaceVars)
+
+		t.Run("it sends only the given variables", func(t *testing.T) {
+			vars := sent()
+
+			if len(vars) != 1 || vars["C"] != "only" {
+				t.Errorf("Unexpected vars: %v", vars)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestAddonService_Destroy(t *testing.T) {
+	account := &Account{ID: "1"}
+	driver := NewMockDriver()
+	service := NewAddonService(driver)
+
+	t.Run("with an invalid addon", func(t *testing.T) {
+		if err := service.Destroy(account, &Addon{}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when successful", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "accounts/1/addons/3", Response{Pages: [][]byte{[]byte(`true`)}})
+
+		if err := service.Destroy(account, &Addon{ID: 3}); err != nil {
+			t.Errorf("Expected no error")
+		}
+	})
+
+	t.Run("when unsuccessful", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "accounts/1/addons/3", Response{Error: fmt.Errorf("Oh no!")})
+
+		if err := service.Destroy(account, &Addon{ID: 3}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestAddonVars_masking(t *testing.T) {
+	addon := &Addon{ID: 1, Name: "logs", Vars: AddonVars{"TOKEN": "supersecret", "URL": "https://example.com"}}
+
+	var logged strings.Builder
+	logger := log.New(&logged, "", 0)
+	logger.Printf("%v %+v", addon, addon)
+
+	formats := map[string]string{
+		"%v":   fmt.Sprintf("%v", addon),
+		"%+v":  fmt.Sprintf("%+v", addon),
+		"%#v":  fmt.Sprintf("%#v", addon),
+		"%s":   fmt.Sprintf("%s", addon.Vars),
+		"log":  logged.String(),
+		"vars": fmt.Sprint(addon.Vars),
+	}
+
+	for format, output := range formats {
+		t.Run("it masks values when formatted with "+format, func(t *testing.T) {
+			if strings.Contains(output, "supersecret") || strings.Contains(output, "example.com") {
+				t.Errorf("Expected values to be masked, got %s", output)
+			}
+
+			if !strings.Contains(output, "TOKEN") {
+				t.Errorf("Expected keys to be visible, got %s", output)
+			}
+		})
+	}
+
+	t.Run("it keeps the real values available", func(t *testing.T) {
+		if addon.Vars["TOKEN"] != "supersecret" {
+			t.Errorf("Expected the real value")
+		}
+	})
+
+	t.Run("it marshals the real values", func(t *testing.T) {
+		encoded, _ := json.Marshal(addon)
+
+		if !strings.Contains(string(encoded), "supersecret") {
+			t.Errorf("Expected the real value in %s", encoded)
+		}
+	})
+}
+
 func stubAddons(driver *MockDriver, addons ...*Addon) {
 	pages := make([][]byte, 0)
 
@@ -94,3 +425,29 @@ func stubAccountAddons(driver *MockDriver, account *Account, addons ...*Addon) {
 		driver.AddResponse("get", "accounts/"+account.ID+"/addons", Response{Pages: pages})
 	}
 }
+
+func stubEnvironmentAddons(driver *MockDriver, environment *Environment, addons ...*Addon) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		Addons []*Addon `json:"addons,omitempty"`
+	}{Addons: addons}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "environments/"+strconv.Itoa(environment.ID)+"/addons", Response{Pages: pages})
+	}
+}
+
+func stubAddon(driver *MockDriver, account *Account, addon *Addon) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		Addon *Addon `json:"addon,omitempty"`
+	}{Addon: addon}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "accounts/"+account.ID+"/addons/"+strconv.Itoa(addon.ID), Response{Pages: pages})
+	}
+}
@@ -2,6 +2,10 @@ package eygo
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Address is a data structure that models an IP address on the Engine Yard
@@ -10,7 +14,7 @@ type Address struct {
 	ID            int    `json:"id,omitempty"`
 	ProvisionedID string `json:"provisioned_id,omitempty"`
 	IPAddress     string `json:"ip_address,omitempty"`
-	Server        string `json:"server,omitempty"`
+	Server        string `json:"-"` // Deprecated: never populated, use ServerURL.
 	Location      string `json:"location,omitempty"`
 	ProviderURL   string `json:"provider,omitempty"`
 	ServerURL     string `json:"server,omitempty"`
@@ -18,8 +22,21 @@ type Address struct {
 	UpdatedAt     string `json:"updated_at,omitempty"`
 }
 
-// AddressService is a repository one can use to retrieve Address records from
-// the API.
+// AttachedTo returns true if the address is attached to the given Server, and
+// false otherwise.
+func (address *Address) AttachedTo(server *Server) bool {
+	if server == nil || len(address.ServerURL) == 0 {
+		return false
+	}
+
+	path := pathFor(address.ServerURL)
+
+	return path == fmt.Sprintf("servers/%d", server.ID) ||
+		strings.HasSuffix(path, fmt.Sprintf("/servers/%d", server.ID))
+}
+
+// AddressService is a repository one can use to retrieve, allocate, attach,
+// and release Address records on the API.
 type AddressService struct {
 	Driver Driver
 }
@@ -41,23 +58,233 @@ func (service *AddressService) ForAccount(account *Account, params Params) []*Ad
 	return service.collection("accounts/"+account.ID+"/addresses", params)
 }
 
+// Find returns the Address record identified by the given address id. If
+// there are errors in retrieving this information, an error is returned as
+// well.
+func (service *AddressService) Find(id string) (*Address, error) {
+	response := service.Driver.Get("addresses/"+id, nil)
+	if response.Okay() {
+		wrapper := struct {
+			Address *Address `json:"address,omitempty"`
+		}{}
+
+		err := json.Unmarshal(response.Pages[0], &wrapper)
+		if err != nil {
+			return nil, err
+		}
+
+		return wrapper.Address, nil
+	}
+
+	return nil, response.Error
+}
+
+// Allocate requests a new Address from the given Provider in the given
+// ProviderLocation. The Request that tracks the allocation is returned. If
+// there are issues along the way, an error is returned.
+func (service *AddressService) Allocate(provider *Provider, location *ProviderLocation) (*Request, error) {
+	if provider == nil || provider.ID == 0 {
+		return nil, fmt.Errorf("No valid provider given")
+	}
+
+	if location == nil || len(location.LocationID) == 0 {
+		return nil, fmt.Errorf("No valid location given")
+	}
+
+	wrapper := struct {
+		Address struct {
+			Location string `json:"location,omitempty"`
+		} `json:"address"`
+	}{}
+	wrapper.Address.Location = location.LocationID
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.request(
+		service.Driver.Post(
+			fmt.Sprintf("providers/%d/addresses", provider.ID),
+			nil,
+			body,
+		),
+	)
+}
+
+// Attach requests that the given Address be attached to the given Server.
+// The Request that tracks the attachment is returned. If there are issues
+// along the way, an error is returned.
+func (service *AddressService) Attach(address *Address, server *Server) (*Request, error) {
+	if address == nil || address.ID == 0 {
+		return nil, fmt.Errorf("No valid address given")
+	}
+
+	if server == nil || server.ID == 0 {
+		return nil, fmt.Errorf("No valid server given")
+	}
+
+	wrapper := struct {
+		Server struct {
+			ID int `json:"id,omitempty"`
+		} `json:"server"`
+	}{}
+	wrapper.Server.ID = server.ID
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.request(
+		service.Driver.Post(
+			fmt.Sprintf("addresses/%d/attach", address.ID),
+			nil,
+			body,
+		),
+	)
+}
+
+// Detach requests that the given Address be detached from its Server. The
+// Request that tracks the detachment is returned. If there are issues along
+// the way, an error is returned.
+func (service *AddressService) Detach(address *Address) (*Request, error) {
+	if address == nil || address.ID == 0 {
+		return nil, fmt.Errorf("No valid address given")
+	}
+
+	return service.request(
+		service.Driver.Post(
+			fmt.Sprintf("addresses/%d/detach", address.ID),
+			Params{},
+			nil,
+		),
+	)
+}
+
+// Release requests that the given Address be returned to its Provider. The
+// Request that tracks the release is returned. If there are issues along the
+// way, an error is returned.
+func (service *AddressService) Release(address *Address) (*Request, error) {
+	if address == nil || address.ID == 0 {
+		return nil, fmt.Errorf("No valid address given")
+	}
+
+	return service.request(
+		service.Driver.Delete(fmt.Sprintf("addresses/%d", address.ID), Params{}),
+	)
+}
+
+// Failover moves the given Address from one Server to another, waiting for
+// each step to finish by polling the API every interval. Each step must
+// finish before the timeout elapses. The Address must currently be attached
+// to the from Server. If the Address can't be attached to the to Server once
+// it is detached, it is reattached to the from Server, and the returned error
+// says whether that worked. If there are issues along the way, an error is
+// returned. Otherwise, the moved Address is returned.
+func (service *AddressService) Failover(address *Address, from *Server, to *Server, interval time.Duration, timeout time.Duration) (*Address, error) {
+	if address == nil || address.ID == 0 {
+		return nil, fmt.Errorf("No valid address given")
+	}
+
+	if to == nil || to.ID == 0 {
+		return nil, fmt.Errorf("No valid target server given")
+	}
+
+	current, err := service.Find(strconv.Itoa(address.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	if current == nil {
+		return nil, fmt.Errorf("Couldn't find address %d", address.ID)
+	}
+
+	if !current.AttachedTo(from) {
+		return nil, fmt.Errorf("Address %d is not attached to the given server", address.ID)
+	}
+
+	requests := NewRequestService(service.Driver)
+
+	detach, err := service.Detach(current)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := requests.Wait(detach, interval, timeout); err != nil {
+		return nil, err
+	}
+
+	if err := service.attachAndWait(current, to, interval, timeout); err != nil {
+		if rollback := service.attachAndWait(current, from, interval, timeout); rollback != nil {
+			return nil, fmt.Errorf(
+				"Address %d is now detached: couldn't attach it to server %d (%s) or reattach it to server %d (%s)",
+				address.ID, to.ID, err, from.ID, rollback,
+			)
+		}
+
+		return nil, fmt.Errorf(
+			"Couldn't attach address %d to server %d, so it was reattached to server %d: %s",
+			address.ID, to.ID, from.ID, err,
+		)
+	}
+
+	return service.Find(strconv.Itoa(address.ID))
+}
+
+func (service *AddressService) attachAndWait(address *Address, server *Server, interval time.Duration, timeout time.Duration) error {
+	attach, err := service.Attach(address, server)
+	if err != nil {
+		return err
+	}
+
+	_, err = NewRequestService(service.Driver).Wait(attach, interval, timeout)
+
+	return err
+}
+
+func (service *AddressService) request(response Response) (*Request, error) {
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		Request *Request `json:"request,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Request, nil
+}
+
 func (service *AddressService) collection(path string, params Params) []*Address {
+	addresses, _ := service.fetch(path, params)
+
+	return addresses
+}
+
+func (service *AddressService) fetch(path string, params Params) ([]*Address, error) {
 	addresses := make([]*Address, 0)
 	response := service.Driver.Get(path, params)
 
-	if response.Okay() {
-		for _, page := range response.Pages {
-			wrapper := struct {
-				Addresses []*Address `json:"addresses,omitempty"`
-			}{}
-
-			if err := json.Unmarshal(page, &wrapper); err == nil {
-				addresses = append(addresses, wrapper.Addresses...)
-			}
+	if !response.Okay() {
+		return addresses, response.Error
+	}
+
+	for _, page := range response.Pages {
+		wrapper := struct {
+			Addresses []*Address `json:"addresses,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(page, &wrapper); err == nil {
+			addresses = append(addresses, wrapper.Addresses...)
 		}
 	}
 
-	return addresses
+	return addresses, nil
 }
 
 /*
@@ -2,8 +2,11 @@ package eygo
 
 import (
 	"encoding/json"
-	//"fmt"
+	"fmt"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewAddressService(t *testing.T) {
@@ -122,6 +125,361 @@ func TestAddressService_ForAccount(t *testing.T) {
 
 }
 
+func TestAddressService_Find(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAddressService(driver)
+	address := &Address{ID: 1, IPAddress: "127.0.0.1"}
+	stubAddress(driver, address)
+
+	t.Run("for a known address", func(t *testing.T) {
+		result, err := service.Find("1")
+
+		t.Run("it is the requested address", func(t *testing.T) {
+			if result.ID != address.ID {
+				t.Errorf("Expected address 1, got address %d", result.ID)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("for an unknown address", func(t *testing.T) {
+		result, err := service.Find("2")
+
+		t.Run("it returns no address", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no address, got address %d", result.ID)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestAddress_AttachedTo(t *testing.T) {
+	address := &Address{ID: 1, ServerURL: "https://api.engineyard.com/servers/12"}
+
+	t.Run("it is true for the attached server", func(t *testing.T) {
+		if !address.AttachedTo(&Server{ID: 12}) {
+			t.Errorf("Expected the address to be attached to server 12")
+		}
+	})
+
+	t.Run("it is false for other servers", func(t *testing.T) {
+		if address.AttachedTo(&Server{ID: 2}) {
+			t.Errorf("Expected the address not to be attached to server 2")
+		}
+	})
+
+	t.Run("it is false for unattached addresses", func(t *testing.T) {
+		if (&Address{ID: 2}).AttachedTo(&Server{ID: 12}) {
+			t.Errorf("Expected the address not to be attached")
+		}
+	})
+}
+
+func TestAddressService_Allocate(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAddressService(driver)
+	provider := &Provider{ID: 1}
+	location := &ProviderLocation{ID: "loc1", LocationID: "us-east-1"}
+
+	t.Run("without a location", func(t *testing.T) {
+		if _, err := service.Allocate(provider, &ProviderLocation{}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when the allocation is requested", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse(
+			"post",
+			"providers/1/addresses",
+			Response{Pages: [][]byte{[]byte(`{"request": {"id": "req1", "type": "provision_address"}}`)}},
+		)
+
+		result, err := service.Allocate(provider, location)
+
+		t.Run("it returns the allocation request", func(t *testing.T) {
+			if result == nil || result.ID != "req1" {
+				t.Errorf("Expected request req1, got %v", result)
+			}
+		})
+
+		t.Run("it requests the given location", func(t *testing.T) {
+			bodies := driver.Bodies("post")
+
+			if len(bodies) != 1 || string(bodies[0]) != `{"address":{"location":"us-east-1"}}` {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when the allocation fails", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("post", "providers/1/addresses", Response{Error: fmt.Errorf("Oh no!")})
+
+		if _, err := service.Allocate(provider, location); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestAddressService_Attach(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAddressService(driver)
+
+	t.Run("without a server", func(t *testing.T) {
+		if _, err := service.Attach(&Address{ID: 1}, nil); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when the attachment is requested", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse(
+			"post",
+			"addresses/1/attach",
+			Response{Pages: [][]byte{[]byte(`{"request": {"id": "req2"}}`)}},
+		)
+
+		result, err := service.Attach(&Address{ID: 1}, &Server{ID: 12})
+
+		t.Run("it returns the attachment request", func(t *testing.T) {
+			if result == nil || result.ID != "req2" {
+				t.Errorf("Expected request req2, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestAddressService_Detach(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAddressService(driver)
+
+	t.Run("when the detachment is requested", func(t *testing.T) {
+		driver.AddResponse(
+			"post",
+			"addresses/1/detach",
+			Response{Pages: [][]byte{[]byte(`{"request": {"id": "req3"}}`)}},
+		)
+
+		result, err := service.Detach(&Address{ID: 1})
+
+		t.Run("it returns the detachment request", func(t *testing.T) {
+			if result == nil || result.ID != "req3" {
+				t.Errorf("Expected request req3, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestAddressService_Release(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAddressService(driver)
+
+	t.Run("without an address", func(t *testing.T) {
+		if _, err := service.Release(&Address{}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when the release is requested", func(t *testing.T) {
+		driver.AddResponse(
+			"delete",
+			"addresses/1",
+			Response{Pages: [][]byte{[]byte(`{"request": {"id": "req4"}}`)}},
+		)
+
+		result, err := service.Release(&Address{ID: 1})
+
+		t.Run("it returns the release request", func(t *testing.T) {
+			if result == nil || result.ID != "req4" {
+				t.Errorf("Expected request req4, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestAddressService_Failover(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAddressService(driver)
+	address := &Address{ID: 1}
+	primary := &Server{ID: 11}
+	standby := &Server{ID: 12}
+	finished := `{"request": {"id": "%s", "successful": true, "finished_at": "2018-06-15T10:00:00Z"}}`
+
+	t.Run("when the address isn't attached to the source server", func(t *testing.T) {
+		driver.Reset()
+		stubAddress(driver, &Address{ID: 1, ServerURL: "https://api.engineyard.com/servers/99"})
+
+		_, err := service.Failover(address, primary, standby, time.Millisecond, time.Second)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it doesn't move the address", func(t *testing.T) {
+			if len(driver.Requests("post")) != 0 {
+				t.Errorf("Expected no post requests")
+			}
+		})
+	})
+
+	t.Run("when every step succeeds", func(t *testing.T) {
+		driver.Reset()
+		stubAddress(driver, &Address{ID: 1, ServerURL: "https://api.engineyard.com/servers/11"})
+		stubAddress(driver, &Address{ID: 1, ServerURL: "https://api.engineyard.com/servers/12"})
+		driver.AddResponse("post", "addresses/1/detach", Response{Pages: [][]byte{[]byte(`{"request": {"id": "detach"}}`)}})
+		driver.AddResponse("post", "addresses/1/attach", Response{Pages: [][]byte{[]byte(`{"request": {"id": "attach"}}`)}})
+		driver.AddResponse("get", "requests/detach", Response{Pages: [][]byte{[]byte(fmt.Sprintf(finished, "detach"))}})
+		driver.AddResponse("get", "requests/attach", Response{Pages: [][]byte{[]byte(`{"request": {"id": "attach"}}`)}})
+		driver.AddResponse("get", "requests/attach", Response{Pages: [][]byte{[]byte(fmt.Sprintf(finished, "attach"))}})
+
+		result, err := service.Failover(address, primary, standby, time.Millisecond, time.Second)
+
+		t.Run("it detaches and then attaches the address", func(t *testing.T) {
+			posts := driver.Requests("post")
+
+			if len(posts) != 2 || posts[0] != "addresses/1/detach" || posts[1] != "addresses/1/attach" {
+				t.Errorf("Unexpected post requests: %v", posts)
+			}
+		})
+
+		t.Run("it returns the moved address", func(t *testing.T) {
+			if result == nil || !result.AttachedTo(standby) {
+				t.Errorf("Expected the address to be attached to the standby, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+	})
+
+	t.Run("when the detachment fails", func(t *testing.T) {
+		driver.Reset()
+		stubAddress(driver, &Address{ID: 1, ServerURL: "https://api.engineyard.com/servers/11"})
+		driver.AddResponse("post", "addresses/1/detach", Response{Pages: [][]byte{[]byte(`{"request": {"id": "detach"}}`)}})
+		driver.AddResponse("get", "requests/detach", Response{Pages: [][]byte{[]byte(`{"request": {"id": "detach", "finished_at": "2018-06-15T10:00:00Z"}}`)}})
+
+		_, err := service.Failover(address, primary, standby, time.Millisecond, time.Second)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it doesn't attach the address", func(t *testing.T) {
+			if posts := driver.Requests("post"); len(posts) != 1 {
+				t.Errorf("Unexpected post requests: %v", posts)
+			}
+		})
+	})
+
+	t.Run("when the address can't be found", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("get", "addresses/1", Response{Pages: [][]byte{[]byte(`{}`)}})
+
+		_, err := service.Failover(address, primary, standby, time.Millisecond, time.Second)
+
+		t.Run("it returns an error without moving the address", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+
+			if len(driver.Requests("post")) != 0 {
+				t.Errorf("Expected no post requests")
+			}
+		})
+	})
+
+	t.Run("when the attachment fails", func(t *testing.T) {
+		failed := `{"request": {"id": "%s", "finished_at": "2018-06-15T10:00:00Z"}}`
+
+		t.Run("and the rollback succeeds", func(t *testing.T) {
+			driver.Reset()
+			stubAddress(driver, &Address{ID: 1, ServerURL: "https://api.engineyard.com/servers/11"})
+			driver.AddResponse("post", "addresses/1/detach", Response{Pages: [][]byte{[]byte(`{"request": {"id": "detach"}}`)}})
+			driver.AddResponse("post", "addresses/1/attach", Response{Pages: [][]byte{[]byte(`{"request": {"id": "attach"}}`)}})
+			driver.AddResponse("post", "addresses/1/attach", Response{Pages: [][]byte{[]byte(`{"request": {"id": "rollback"}}`)}})
+			driver.AddResponse("get", "requests/detach", Response{Pages: [][]byte{[]byte(fmt.Sprintf(finished, "detach"))}})
+			driver.AddResponse("get", "requests/attach", Response{Pages: [][]byte{[]byte(fmt.Sprintf(failed, "attach"))}})
+			driver.AddResponse("get", "requests/rollback", Response{Pages: [][]byte{[]byte(fmt.Sprintf(finished, "rollback"))}})
+
+			_, err := service.Failover(address, primary, standby, time.Millisecond, time.Second)
+
+			t.Run("it reattaches the address to the source server", func(t *testing.T) {
+				bodies := driver.Bodies("post")
+
+				if len(bodies) != 3 || string(bodies[2]) != `{"server":{"id":11}}` {
+					t.Errorf("Expected a reattachment to server 11, got %d posts", len(bodies))
+				}
+			})
+
+			t.Run("it returns an error that mentions the reattachment", func(t *testing.T) {
+				if err == nil || !strings.Contains(err.Error(), "reattached") {
+					t.Errorf("Expected a reattachment error, got %v", err)
+				}
+			})
+		})
+
+		t.Run("and the rollback fails", func(t *testing.T) {
+			driver.Reset()
+			stubAddress(driver, &Address{ID: 1, ServerURL: "https://api.engineyard.com/servers/11"})
+			driver.AddResponse("post", "addresses/1/detach", Response{Pages: [][]byte{[]byte(`{"request": {"id": "detach"}}`)}})
+			driver.AddResponse("post", "addresses/1/attach", Response{Error: fmt.Errorf("Oh no!")})
+			driver.AddResponse("post", "addresses/1/attach", Response{Error: fmt.Errorf("Oh no!")})
+			driver.AddResponse("get", "requests/detach", Response{Pages: [][]byte{[]byte(fmt.Sprintf(finished, "detach"))}})
+
+			_, err := service.Failover(address, primary, standby, time.Millisecond, time.Second)
+
+			t.Run("it returns an error that says the address is detached", func(t *testing.T) {
+				if err == nil || !strings.Contains(err.Error(), "now detached") {
+					t.Errorf("Expected a detached error, got %v", err)
+				}
+			})
+		})
+	})
+}
+
 func stubAddresss(driver *MockDriver, addresss ...*Address) {
 	pages := make([][]byte, 0)
 
@@ -147,3 +505,16 @@ func stubAccountAddresss(driver *MockDriver, account *Account, addresss ...*Addr
 		driver.AddResponse("get", "accounts/"+account.ID+"/addresses", Response{Pages: pages})
 	}
 }
+
+func stubAddress(driver *MockDriver, address *Address) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		Address *Address `json:"address,omitempty"`
+	}{Address: address}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "addresses/"+strconv.Itoa(address.ID), Response{Pages: pages})
+	}
+}
@@ -3,6 +3,7 @@ package eygo
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // Alert is a data structure that models an alert on the Engine Yard API
@@ -25,6 +26,91 @@ type Alert struct {
 	EnvironmentURL string `json:"environment,omitempty"`
 }
 
+// Open returns true if the alert has not yet finished, and false otherwise.
+func (alert *Alert) Open() bool {
+	return len(alert.FinishedAt) == 0
+}
+
+const (
+	// OpenAlerts is an AlertFilter state that matches alerts that have not
+	// yet finished.
+	OpenAlerts = "open"
+
+	// FinishedAlerts is an AlertFilter state that matches alerts that have
+	// finished.
+	FinishedAlerts = "finished"
+)
+
+// AlertFilter is a data structure that describes the criteria used to narrow
+// down an array of Alert records. Empty criteria match every alert.
+type AlertFilter struct {
+	// Severities is a list of acceptable severities, compared without regard
+	// to case.
+	Severities []string
+
+	// Types is a list of acceptable alert types.
+	Types []string
+
+	// State is either OpenAlerts, FinishedAlerts, or empty for both.
+	State string
+
+	// ResourceURL limits the matches to alerts about the given resource.
+	ResourceURL string
+}
+
+// Match returns true if the given Alert satisfies the filter, and false
+// otherwise.
+func (filter AlertFilter) Match(alert *Alert) bool {
+	if len(filter.Severities) > 0 && !containsFold(filter.Severities, alert.Severity) {
+		return false
+	}
+
+	if len(filter.Types) > 0 && !containsFold(filter.Types, alert.Type) {
+		return false
+	}
+
+	switch filter.State {
+	case OpenAlerts:
+		if !alert.Open() {
+			return false
+		}
+	case FinishedAlerts:
+		if alert.Open() {
+			return false
+		}
+	}
+
+	if len(filter.ResourceURL) > 0 && pathFor(filter.ResourceURL) != pathFor(alert.ResourceURL) {
+		return false
+	}
+
+	return true
+}
+
+// FilterAlerts returns the Alert records from the given array that satisfy
+// the given AlertFilter.
+func FilterAlerts(alerts []*Alert, filter AlertFilter) []*Alert {
+	matches := make([]*Alert, 0)
+
+	for _, alert := range alerts {
+		if filter.Match(alert) {
+			matches = append(matches, alert)
+		}
+	}
+
+	return matches
+}
+
+func containsFold(values []string, candidate string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, candidate) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // AlertService is a repository that one can use to create, retrieve, delete,
 // and perform other operations on Alert records on the API.
 type AlertService struct {
@@ -50,6 +136,28 @@ func (service *AlertService) ForEnvironment(environment *Environment, params Par
 	)
 }
 
+// ForAccount returns an array of Alert records that are both associated with
+// the given Account and matching the given Params.
+func (service *AlertService) ForAccount(account *Account, params Params) []*Alert {
+	return service.collection("accounts/"+account.ID+"/alerts", params)
+}
+
+// ForServer returns an array of Alert records that are both about the given
+// Server and matching the given Params.
+func (service *AlertService) ForServer(server *Server, params Params) []*Alert {
+	return service.collection(
+		fmt.Sprintf("servers/%d/alerts", server.ID),
+		params,
+	)
+}
+
+// ForResource returns an array of Alert records that are both about the
+// resource at the given URL (as found in Alert.ResourceURL) and matching the
+// given Params.
+func (service *AlertService) ForResource(resourceURL string, params Params) []*Alert {
+	return service.collection(pathFor(resourceURL)+"/alerts", params)
+}
+
 // Find returns the Alert record identified by the given alert id. If there
 // are errors in retrieving this information, an error is returned as well.
 func (service *AlertService) Find(id string) (*Alert, error) {
@@ -70,23 +178,94 @@ func (service *AlertService) Find(id string) (*Alert, error) {
 	return nil, response.Error
 }
 
+type alertFlags struct {
+	Acknowledged *bool `json:"acknowledged,omitempty"`
+	Ignored      *bool `json:"ignored,omitempty"`
+}
+
+// Acknowledge marks the given Alert as acknowledged on the upstream API. If
+// there are issues along the way, an error is returned. Otherwise, the
+// updated Alert is returned.
+func (service *AlertService) Acknowledge(alert *Alert) (*Alert, error) {
+	acknowledged := true
+
+	return service.update(alert, &alertFlags{Acknowledged: &acknowledged})
+}
+
+// Unacknowledge clears the acknowledgement of the given Alert on the upstream
+// API. If there are issues along the way, an error is returned. Otherwise, the
+// updated Alert is returned.
+func (service *AlertService) Unacknowledge(alert *Alert) (*Alert, error) {
+	acknowledged := false
+
+	return service.update(alert, &alertFlags{Acknowledged: &acknowledged})
+}
+
+// Ignore marks the given Alert as ignored on the upstream API. If there are
+// issues along the way, an error is returned. Otherwise, the updated Alert is
+// returned.
+func (service *AlertService) Ignore(alert *Alert) (*Alert, error) {
+	ignored := true
+
+	return service.update(alert, &alertFlags{Ignored: &ignored})
+}
+
+func (service *AlertService) update(alert *Alert, flags *alertFlags) (*Alert, error) {
+	if alert == nil || len(alert.ID) == 0 {
+		return nil, fmt.Errorf("can't update an alert without an ID")
+	}
+
+	wrapper := struct {
+		Alert *alertFlags `json:"alert,omitempty"`
+	}{Alert: flags}
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	response := service.Driver.Put("alerts/"+alert.ID, nil, body)
+	if response.Okay() {
+		wrapped := struct {
+			Alert *Alert `json:"alert,omitempty"`
+		}{}
+
+		err := json.Unmarshal(response.Pages[0], &wrapped)
+		if err != nil {
+			return nil, err
+		}
+
+		return wrapped.Alert, nil
+	}
+
+	return nil, response.Error
+}
+
 func (service *AlertService) collection(path string, params Params) []*Alert {
+	alerts, _ := service.fetch(path, params)
+
+	return alerts
+}
+
+func (service *AlertService) fetch(path string, params Params) ([]*Alert, error) {
 	alerts := make([]*Alert, 0)
 	response := service.Driver.Get(path, params)
 
-	if response.Okay() {
-		for _, page := range response.Pages {
-			wrapper := struct {
-				Alerts []*Alert `json:"alerts,omitempty"`
-			}{}
-
-			if err := json.Unmarshal(page, &wrapper); err == nil {
-				alerts = append(alerts, wrapper.Alerts...)
-			}
+	if !response.Okay() {
+		return alerts, response.Error
+	}
+
+	for _, page := range response.Pages {
+		wrapper := struct {
+			Alerts []*Alert `json:"alerts,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(page, &wrapper); err == nil {
+			alerts = append(alerts, wrapper.Alerts...)
 		}
 	}
 
-	return alerts
+	return alerts, nil
 }
 
 /*
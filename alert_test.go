@@ -2,7 +2,7 @@ package eygo
 
 import (
 	"encoding/json"
-	//"fmt"
+	"fmt"
 	"strconv"
 	"testing"
 )
@@ -162,6 +162,283 @@ func TestAlertService_ForEnvironment(t *testing.T) {
 
 }
 
+func TestAlertService_ForAccount(t *testing.T) {
+	account := &Account{ID: "1", Name: "Account 1"}
+	driver := NewMockDriver()
+	service := NewAlertService(driver)
+
+	t.Run("when there are matching alerts", func(t *testing.T) {
+		alert1 := &Alert{ID: "Alert 1"}
+		alert2 := &Alert{ID: "Alert 2"}
+
+		stubAccountAlerts(driver, account, alert1, alert2)
+
+		all := service.ForAccount(account, nil)
+
+		t.Run("it contains all matching alerts", func(t *testing.T) {
+			if len(all) != 2 || all[0].ID != alert1.ID || all[1].ID != alert2.ID {
+				t.Errorf("Expected alerts 1 and 2, got %v", all)
+			}
+		})
+	})
+
+	t.Run("when there are no matching alerts", func(t *testing.T) {
+		driver.Reset()
+
+		t.Run("it is empty", func(t *testing.T) {
+			all := service.ForAccount(account, nil)
+
+			if len(all) != 0 {
+				t.Errorf("Expected 0 alerts, got %d", len(all))
+			}
+		})
+	})
+}
+
+func TestAlertService_ForServer(t *testing.T) {
+	server := &Server{ID: 3}
+	driver := NewMockDriver()
+	service := NewAlertService(driver)
+
+	t.Run("when there are matching alerts", func(t *testing.T) {
+		stubResourceAlerts(driver, "servers/3", &Alert{ID: "Alert 1"})
+
+		all := service.ForServer(server, nil)
+
+		t.Run("it contains all matching alerts", func(t *testing.T) {
+			if len(all) != 1 || all[0].ID != "Alert 1" {
+				t.Errorf("Expected alert 1, got %v", all)
+			}
+		})
+	})
+
+	t.Run("when there are no matching alerts", func(t *testing.T) {
+		driver.Reset()
+
+		t.Run("it is empty", func(t *testing.T) {
+			if all := service.ForServer(server, nil); len(all) != 0 {
+				t.Errorf("Expected 0 alerts, got %d", len(all))
+			}
+		})
+	})
+}
+
+func TestAlertService_ForResource(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAlertService(driver)
+
+	t.Run("for a resource URL", func(t *testing.T) {
+		stubResourceAlerts(driver, "servers/3", &Alert{ID: "Alert 1"})
+
+		all := service.ForResource("https://api.engineyard.com/servers/3", nil)
+
+		t.Run("it contains the alerts for the resource", func(t *testing.T) {
+			if len(all) != 1 || all[0].ID != "Alert 1" {
+				t.Errorf("Expected alert 1, got %v", all)
+			}
+		})
+	})
+}
+
+func TestAlertService_Acknowledge(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAlertService(driver)
+	alert := &Alert{ID: "abc"}
+
+	t.Run("without an ID", func(t *testing.T) {
+		_, err := service.Acknowledge(&Alert{})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when the update is successful", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse(
+			"put",
+			"alerts/abc",
+			Response{Pages: [][]byte{[]byte(`{"alert": {"id": "abc", "acknowledged": true}}`)}},
+		)
+
+		result, err := service.Acknowledge(alert)
+
+		t.Run("it returns the acknowledged alert", func(t *testing.T) {
+			if result == nil || !result.Acknowledged {
+				t.Errorf("Expected an acknowledged alert")
+			}
+		})
+
+		t.Run("it sends the acknowledgement", func(t *testing.T) {
+			bodies := driver.Bodies("put")
+
+			if len(bodies) != 1 || string(bodies[0]) != `{"alert":{"acknowledged":true}}` {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when the update fails", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("put", "alerts/abc", Response{Error: fmt.Errorf("Oh no!")})
+
+		result, err := service.Acknowledge(alert)
+
+		t.Run("it returns no alert", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no alert, got %s", result.ID)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestAlertService_Unacknowledge(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAlertService(driver)
+
+	driver.AddResponse(
+		"put",
+		"alerts/abc",
+		Response{Pages: [][]byte{[]byte(`{"alert": {"id": "abc"}}`)}},
+	)
+
+	result, err := service.Unacknowledge(&Alert{ID: "abc", Acknowledged: true})
+
+	t.Run("it returns the unacknowledged alert", func(t *testing.T) {
+		if result == nil || result.Acknowledged {
+			t.Errorf("Expected an unacknowledged alert")
+		}
+	})
+
+	t.Run("it explicitly clears the acknowledgement", func(t *testing.T) {
+		bodies := driver.Bodies("put")
+
+		if len(bodies) != 1 || string(bodies[0]) != `{"alert":{"acknowledged":false}}` {
+			t.Errorf("Unexpected request bodies: %s", bodies)
+		}
+	})
+
+	t.Run("it returns no error", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("Expected no error")
+		}
+	})
+}
+
+func TestAlertService_Ignore(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAlertService(driver)
+
+	driver.AddResponse(
+		"put",
+		"alerts/abc",
+		Response{Pages: [][]byte{[]byte(`{"alert": {"id": "abc", "ignored": true}}`)}},
+	)
+
+	result, err := service.Ignore(&Alert{ID: "abc"})
+
+	t.Run("it returns the ignored alert", func(t *testing.T) {
+		if result == nil || !result.Ignored {
+			t.Errorf("Expected an ignored alert")
+		}
+	})
+
+	t.Run("it sends the ignore flag", func(t *testing.T) {
+		bodies := driver.Bodies("put")
+
+		if len(bodies) != 1 || string(bodies[0]) != `{"alert":{"ignored":true}}` {
+			t.Errorf("Unexpected request bodies: %s", bodies)
+		}
+	})
+
+	t.Run("it returns no error", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("Expected no error")
+		}
+	})
+}
+
+func TestFilterAlerts(t *testing.T) {
+	alerts := []*Alert{
+		{ID: "1", Severity: "FAILURE", Type: "server", ResourceURL: "https://api.engineyard.com/servers/1"},
+		{ID: "2", Severity: "WARNING", Type: "server", ResourceURL: "https://api.engineyard.com/servers/2", FinishedAt: "2018-06-15T10:00:00Z"},
+		{ID: "3", Severity: "failure", Type: "environment", FinishedAt: "2018-06-15T10:00:00Z"},
+	}
+
+	ids := func(matches []*Alert) string {
+		result := ""
+		for _, alert := range matches {
+			result = result + alert.ID
+		}
+
+		return result
+	}
+
+	t.Run("with an empty filter", func(t *testing.T) {
+		t.Run("it matches everything", func(t *testing.T) {
+			if matches := ids(FilterAlerts(alerts, AlertFilter{})); matches != "123" {
+				t.Errorf("Expected alerts 123, got %s", matches)
+			}
+		})
+	})
+
+	t.Run("by severity", func(t *testing.T) {
+		t.Run("it ignores case", func(t *testing.T) {
+			if matches := ids(FilterAlerts(alerts, AlertFilter{Severities: []string{"Failure"}})); matches != "13" {
+				t.Errorf("Expected alerts 13, got %s", matches)
+			}
+		})
+	})
+
+	t.Run("by type", func(t *testing.T) {
+		if matches := ids(FilterAlerts(alerts, AlertFilter{Types: []string{"server"}})); matches != "12" {
+			t.Errorf("Expected alerts 12, got %s", matches)
+		}
+	})
+
+	t.Run("by state", func(t *testing.T) {
+		t.Run("it matches open alerts", func(t *testing.T) {
+			if matches := ids(FilterAlerts(alerts, AlertFilter{State: OpenAlerts})); matches != "1" {
+				t.Errorf("Expected alert 1, got %s", matches)
+			}
+		})
+
+		t.Run("it matches finished alerts", func(t *testing.T) {
+			if matches := ids(FilterAlerts(alerts, AlertFilter{State: FinishedAlerts})); matches != "23" {
+				t.Errorf("Expected alerts 23, got %s", matches)
+			}
+		})
+	})
+
+	t.Run("by resource", func(t *testing.T) {
+		if matches := ids(FilterAlerts(alerts, AlertFilter{ResourceURL: "servers/2"})); matches != "2" {
+			t.Errorf("Expected alert 2, got %s", matches)
+		}
+	})
+
+	t.Run("by several criteria", func(t *testing.T) {
+		filter := AlertFilter{Severities: []string{"failure"}, State: FinishedAlerts}
+
+		if matches := ids(FilterAlerts(alerts, filter)); matches != "3" {
+			t.Errorf("Expected alert 3, got %s", matches)
+		}
+	})
+}
+
 func stubAlerts(driver *MockDriver, alerts ...*Alert) {
 	pages := make([][]byte, 0)
 
@@ -200,3 +477,29 @@ func stubAlert(driver *MockDriver, alert *Alert) {
 		driver.AddResponse("get", "alerts/"+alert.ID, Response{Pages: pages})
 	}
 }
+
+func stubAccountAlerts(driver *MockDriver, account *Account, alerts ...*Alert) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		Alerts []*Alert `json:"alerts,omitempty"`
+	}{Alerts: alerts}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "accounts/"+account.ID+"/alerts", Response{Pages: pages})
+	}
+}
+
+func stubResourceAlerts(driver *MockDriver, resource string, alerts ...*Alert) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		Alerts []*Alert `json:"alerts,omitempty"`
+	}{Alerts: alerts}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", resource+"/alerts", Response{Pages: pages})
+	}
+}
@@ -0,0 +1,331 @@
+package eygo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AlertEventType describes the kind of change that an AlertEvent reports.
+type AlertEventType string
+
+const (
+	// AlertOpened is the type of events about alerts that were not previously
+	// known to be open.
+	AlertOpened AlertEventType = "opened"
+
+	// AlertAcknowledged is the type of events about open alerts that have
+	// become acknowledged.
+	AlertAcknowledged AlertEventType = "acknowledged"
+
+	// AlertEscalated is the type of events about open alerts whose severity
+	// has increased.
+	AlertEscalated AlertEventType = "escalated"
+
+	// AlertResolved is the type of events about alerts that have finished or
+	// are no longer reported by the API.
+	AlertResolved AlertEventType = "resolved"
+)
+
+// AlertEvent is a data structure that describes a change in the state of an
+// Alert, as observed by an AlertWatcher.
+type AlertEvent struct {
+	Type             AlertEventType
+	Alert            *Alert
+	PreviousSeverity string
+}
+
+// AlertCursor is a data structure that records the alerts that an
+// AlertWatcher knows to be open. It can be marshaled to JSON, saved, and
+// given to a later AlertWatcher so that it resumes where the previous one
+// left off.
+type AlertCursor struct {
+	Alerts map[string]*Alert `json:"alerts"`
+}
+
+// DefaultAlertInterval is the time that an AlertWatcher waits between polls
+// if it isn't given a positive Interval.
+const DefaultAlertInterval = time.Minute
+
+// AlertWatcher polls the API for alerts and reports changes to them as
+// AlertEvents. Alerts are tracked by ID, and alerts that share an ExternalID
+// are treated as the same alert. When several alerts share an ExternalID, an
+// open alert is preferred over a finished one, and the newest is preferred
+// over older ones.
+type AlertWatcher struct {
+	// Alerts is the AlertService used to retrieve alerts.
+	Alerts *AlertService
+
+	// Interval is the time to wait between polls. If it isn't positive,
+	// DefaultAlertInterval is used.
+	Interval time.Duration
+
+	// OnError, if given, is called by Watch with the error from every poll
+	// that fails.
+	OnError func(error)
+
+	// Environment, if given, limits the watcher to the alerts for that
+	// Environment.
+	Environment *Environment
+
+	// Params are passed along when alerts are retrieved.
+	Params Params
+
+	mutex sync.Mutex
+	known map[string]*Alert
+}
+
+// NewAlertWatcher returns an AlertWatcher that uses the given AlertService and
+// polls at the given interval. If the interval isn't positive,
+// DefaultAlertInterval is used instead.
+func NewAlertWatcher(alerts *AlertService, interval time.Duration) *AlertWatcher {
+	if interval <= 0 {
+		interval = DefaultAlertInterval
+	}
+
+	return &AlertWatcher{
+		Alerts:   alerts,
+		Interval: interval,
+		known:    make(map[string]*Alert),
+	}
+}
+
+// Resume replaces the watcher's knowledge of open alerts with the contents of
+// the given AlertCursor.
+func (watcher *AlertWatcher) Resume(cursor *AlertCursor) {
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+
+	watcher.known = make(map[string]*Alert)
+
+	if cursor == nil {
+		return
+	}
+
+	for key, alert := range cursor.Alerts {
+		watcher.known[key] = alert
+	}
+}
+
+// Cursor returns an AlertCursor that describes the alerts that the watcher
+// currently knows to be open.
+func (watcher *AlertWatcher) Cursor() *AlertCursor {
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+
+	cursor := &AlertCursor{Alerts: make(map[string]*Alert)}
+
+	for key, alert := range watcher.known {
+		cursor.Alerts[key] = alert
+	}
+
+	return cursor
+}
+
+// Watch polls the API immediately and then once per Interval, sending an
+// AlertEvent on the returned channel for every change that it observes. Polls
+// that fail are reported to OnError, if it is given, and otherwise skipped.
+// The channel is closed once the given context is done.
+func (watcher *AlertWatcher) Watch(ctx context.Context) <-chan *AlertEvent {
+	events := make(chan *AlertEvent)
+
+	interval := watcher.Interval
+	if interval <= 0 {
+		interval = DefaultAlertInterval
+	}
+
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			polled, err := watcher.Poll()
+			if err != nil && watcher.OnError != nil {
+				watcher.OnError(err)
+			}
+
+			for _, event := range polled {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// Poll retrieves the current alerts once, updates the watcher's knowledge of
+// open alerts, and returns the AlertEvents that describe the changes since the
+// previous poll. If the alerts can't be retrieved, the watcher's knowledge is
+// left untouched and an error is returned.
+func (watcher *AlertWatcher) Poll() ([]*AlertEvent, error) {
+	current, err := watcher.fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+
+	if watcher.known == nil {
+		watcher.known = make(map[string]*Alert)
+	}
+
+	events := make([]*AlertEvent, 0)
+	seen := make(map[string]bool)
+
+	for _, alert := range representativeAlerts(current) {
+		key := alertKey(alert)
+		seen[key] = true
+
+		previous, tracked := watcher.known[key]
+
+		if !alert.Open() {
+			if tracked {
+				events = append(events, &AlertEvent{Type: AlertResolved, Alert: alert})
+				delete(watcher.known, key)
+			}
+
+			continue
+		}
+
+		watcher.known[key] = alert
+
+		if !tracked {
+			events = append(events, &AlertEvent{Type: AlertOpened, Alert: alert})
+			continue
+		}
+
+		if alert.Acknowledged && !previous.Acknowledged {
+			events = append(events, &AlertEvent{Type: AlertAcknowledged, Alert: alert})
+		}
+
+		if severityRank(alert.Severity) > severityRank(previous.Severity) {
+			events = append(
+				events,
+				&AlertEvent{
+					Type:             AlertEscalated,
+					Alert:            alert,
+					PreviousSeverity: previous.Severity,
+				},
+			)
+		}
+	}
+
+	missing := make([]string, 0)
+	for key := range watcher.known {
+		if !seen[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+
+	for _, key := range missing {
+		events = append(events, &AlertEvent{Type: AlertResolved, Alert: watcher.known[key]})
+		delete(watcher.known, key)
+	}
+
+	return events, nil
+}
+
+func (watcher *AlertWatcher) fetch() ([]*Alert, error) {
+	if watcher.Environment != nil {
+		return watcher.Alerts.fetch(
+			fmt.Sprintf("environments/%d/alerts", watcher.Environment.ID),
+			watcher.Params,
+		)
+	}
+
+	return watcher.Alerts.fetch("alerts", watcher.Params)
+}
+
+// representativeAlerts returns one alert per alert key, in the order that the
+// keys first appear. Open alerts are preferred over finished ones, and newer
+// alerts over older ones.
+func representativeAlerts(alerts []*Alert) []*Alert {
+	chosen := make(map[string]*Alert)
+	order := make([]string, 0)
+
+	for _, alert := range alerts {
+		key := alertKey(alert)
+
+		existing, found := chosen[key]
+		if !found {
+			order = append(order, key)
+		}
+
+		if !found || preferAlert(alert, existing) {
+			chosen[key] = alert
+		}
+	}
+
+	representatives := make([]*Alert, 0, len(order))
+	for _, key := range order {
+		representatives = append(representatives, chosen[key])
+	}
+
+	return representatives
+}
+
+// preferAlert returns true if the candidate alert should stand for its key
+// instead of the existing one.
+func preferAlert(candidate *Alert, existing *Alert) bool {
+	if candidate.Open() != existing.Open() {
+		return candidate.Open()
+	}
+
+	candidateCreated, candidateErr := parseTimestamp(candidate.CreatedAt)
+	existingCreated, existingErr := parseTimestamp(existing.CreatedAt)
+
+	return candidateErr == nil && existingErr == nil && candidateCreated.After(existingCreated)
+}
+
+func alertKey(alert *Alert) string {
+	if len(alert.ExternalID) > 0 {
+		return "external:" + alert.ExternalID
+	}
+
+	return "id:" + alert.ID
+}
+
+var severityRanks = map[string]int{
+	"info":     1,
+	"warning":  2,
+	"error":    3,
+	"failure":  3,
+	"critical": 4,
+}
+
+func severityRank(severity string) int {
+	return severityRanks[strings.ToLower(severity)]
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
@@ -0,0 +1,330 @@
+package eygo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNewAlertWatcher(t *testing.T) {
+	service := NewAlertService(NewMockDriver())
+	watcher := NewAlertWatcher(service, time.Minute)
+
+	t.Run("it is configured with the given service", func(t *testing.T) {
+		if watcher.Alerts != service {
+			t.Errorf("Expected the watcher to use the given service")
+		}
+	})
+
+	t.Run("it is configured with the given interval", func(t *testing.T) {
+		if watcher.Interval != time.Minute {
+			t.Errorf("Expected an interval of one minute, got %s", watcher.Interval)
+		}
+	})
+
+	t.Run("it uses the default interval for non-positive intervals", func(t *testing.T) {
+		if other := NewAlertWatcher(service, 0); other.Interval != DefaultAlertInterval {
+			t.Errorf("Expected the default interval, got %s", other.Interval)
+		}
+	})
+}
+
+func TestAlertWatcher_Poll(t *testing.T) {
+	driver := NewMockDriver()
+	watcher := NewAlertWatcher(NewAlertService(driver), time.Minute)
+
+	t.Run("on the first poll", func(t *testing.T) {
+		stubAlerts(
+			driver,
+			&Alert{ID: "1", Severity: "WARNING"},
+			&Alert{ID: "2", Severity: "WARNING", FinishedAt: "2018-06-15T10:00:00Z"},
+			&Alert{ID: "3", ExternalID: "ext", Severity: "WARNING"},
+			&Alert{ID: "4", ExternalID: "ext", Severity: "WARNING"},
+		)
+
+		events, err := watcher.Poll()
+
+		t.Run("it reports open alerts as opened", func(t *testing.T) {
+			if types := alertEventSummary(events); types != "opened:1 opened:3" {
+				t.Errorf("Unexpected events: %s", types)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when alerts change", func(t *testing.T) {
+		stubAlerts(
+			driver,
+			&Alert{ID: "1", Severity: "FAILURE", Acknowledged: true},
+			&Alert{ID: "4", ExternalID: "ext", Severity: "WARNING"},
+			&Alert{ID: "5", Severity: "INFO"},
+		)
+
+		events, _ := watcher.Poll()
+
+		t.Run("it reports acknowledgements, escalations, and new alerts", func(t *testing.T) {
+			if types := alertEventSummary(events); types != "acknowledged:1 escalated:1 opened:5" {
+				t.Errorf("Unexpected events: %s", types)
+			}
+		})
+
+		t.Run("it records the previous severity of escalated alerts", func(t *testing.T) {
+			if events[1].PreviousSeverity != "WARNING" {
+				t.Errorf("Expected WARNING, got %s", events[1].PreviousSeverity)
+			}
+		})
+	})
+
+	t.Run("when alerts finish or disappear", func(t *testing.T) {
+		stubAlerts(
+			driver,
+			&Alert{ID: "1", Severity: "FAILURE", Acknowledged: true, FinishedAt: "2018-06-15T10:00:00Z"},
+			&Alert{ID: "5", Severity: "INFO"},
+		)
+
+		events, _ := watcher.Poll()
+
+		t.Run("it reports them as resolved", func(t *testing.T) {
+			if types := alertEventSummary(events); types != "resolved:1 resolved:4" {
+				t.Errorf("Unexpected events: %s", types)
+			}
+		})
+	})
+
+	t.Run("when nothing changes", func(t *testing.T) {
+		stubAlerts(driver, &Alert{ID: "5", Severity: "INFO"})
+
+		events, _ := watcher.Poll()
+
+		t.Run("it reports nothing", func(t *testing.T) {
+			if len(events) != 0 {
+				t.Errorf("Unexpected events: %s", alertEventSummary(events))
+			}
+		})
+	})
+
+	t.Run("when the alerts can't be retrieved", func(t *testing.T) {
+		driver.AddResponse("get", "alerts", Response{Error: fmt.Errorf("Oh no!")})
+
+		events, err := watcher.Poll()
+
+		t.Run("it reports nothing", func(t *testing.T) {
+			if len(events) != 0 {
+				t.Errorf("Unexpected events: %s", alertEventSummary(events))
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it keeps tracking known alerts", func(t *testing.T) {
+			if _, found := watcher.Cursor().Alerts["id:5"]; !found {
+				t.Errorf("Expected alert 5 to still be tracked")
+			}
+		})
+	})
+}
+
+func TestAlertWatcher_Poll_SharedExternalID(t *testing.T) {
+	driver := NewMockDriver()
+	watcher := NewAlertWatcher(NewAlertService(driver), time.Minute)
+
+	stubAlerts(driver, &Alert{ID: "1", ExternalID: "ext", Severity: "WARNING"})
+	watcher.Poll()
+
+	t.Run("when a finished alert is listed before a new open one", func(t *testing.T) {
+		stubAlerts(
+			driver,
+			&Alert{ID: "1", ExternalID: "ext", Severity: "WARNING", FinishedAt: "2018-06-15T10:00:00Z"},
+			&Alert{ID: "2", ExternalID: "ext", Severity: "WARNING"},
+		)
+
+		events, _ := watcher.Poll()
+
+		t.Run("it doesn't report the alert as resolved", func(t *testing.T) {
+			if len(events) != 0 {
+				t.Errorf("Unexpected events: %s", alertEventSummary(events))
+			}
+		})
+
+		t.Run("it tracks the open alert", func(t *testing.T) {
+			if tracked := watcher.Cursor().Alerts["external:ext"]; tracked == nil || tracked.ID != "2" {
+				t.Errorf("Expected alert 2 to be tracked")
+			}
+		})
+	})
+
+	t.Run("when the open alert escalates", func(t *testing.T) {
+		stubAlerts(
+			driver,
+			&Alert{ID: "1", ExternalID: "ext", Severity: "WARNING", FinishedAt: "2018-06-15T10:00:00Z"},
+			&Alert{ID: "2", ExternalID: "ext", Severity: "FAILURE"},
+		)
+
+		events, _ := watcher.Poll()
+
+		t.Run("it reports the escalation", func(t *testing.T) {
+			if types := alertEventSummary(events); types != "escalated:2" {
+				t.Errorf("Unexpected events: %s", types)
+			}
+		})
+	})
+
+	t.Run("when several open alerts share a key", func(t *testing.T) {
+		stubAlerts(
+			driver,
+			&Alert{ID: "3", ExternalID: "ext", Severity: "FAILURE", CreatedAt: "2018-06-15T10:00:00Z"},
+			&Alert{ID: "2", ExternalID: "ext", Severity: "FAILURE", CreatedAt: "2018-06-15T11:00:00Z"},
+		)
+
+		watcher.Poll()
+
+		t.Run("it tracks the newest one", func(t *testing.T) {
+			if tracked := watcher.Cursor().Alerts["external:ext"]; tracked == nil || tracked.ID != "2" {
+				t.Errorf("Expected alert 2 to be tracked")
+			}
+		})
+	})
+}
+
+func TestAlertWatcher_Resume(t *testing.T) {
+	driver := NewMockDriver()
+	original := NewAlertWatcher(NewAlertService(driver), time.Minute)
+
+	stubAlerts(driver, &Alert{ID: "1", Severity: "WARNING"})
+	original.Poll()
+
+	saved, err := json.Marshal(original.Cursor())
+	if err != nil {
+		t.Fatalf("Couldn't marshal the cursor: %s", err)
+	}
+
+	cursor := &AlertCursor{}
+	if err := json.Unmarshal(saved, cursor); err != nil {
+		t.Fatalf("Couldn't unmarshal the cursor: %s", err)
+	}
+
+	resumed := NewAlertWatcher(NewAlertService(driver), time.Minute)
+	resumed.Resume(cursor)
+
+	stubAlerts(driver, &Alert{ID: "1", Severity: "WARNING"}, &Alert{ID: "2", Severity: "INFO"})
+	events, _ := resumed.Poll()
+
+	t.Run("it only reports changes since the cursor", func(t *testing.T) {
+		if types := alertEventSummary(events); types != "opened:2" {
+			t.Errorf("Unexpected events: %s", types)
+		}
+	})
+}
+
+func TestAlertWatcher_Watch(t *testing.T) {
+	driver := NewMockDriver()
+	watcher := NewAlertWatcher(NewAlertService(driver), time.Millisecond)
+	watcher.Environment = &Environment{ID: 1}
+
+	stubEnvironmentAlerts(driver, watcher.Environment, &Alert{ID: "1", Severity: "WARNING"})
+	stubEnvironmentAlerts(driver, watcher.Environment)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := watcher.Watch(ctx)
+
+	t.Run("it streams events as they are observed", func(t *testing.T) {
+		received := make([]*AlertEvent, 0)
+
+		for len(received) < 2 {
+			select {
+			case event := <-events:
+				received = append(received, event)
+			case <-time.After(time.Second):
+				t.Fatalf("Timed out waiting for events")
+			}
+		}
+
+		if types := alertEventSummary(received); types != "opened:1 resolved:1" {
+			t.Errorf("Unexpected events: %s", types)
+		}
+	})
+
+	t.Run("it closes the channel when the context is done", func(t *testing.T) {
+		cancel()
+
+		for {
+			select {
+			case _, open := <-events:
+				if !open {
+					return
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("Expected the channel to be closed")
+			}
+		}
+	})
+}
+
+func TestAlertWatcher_Watch_Errors(t *testing.T) {
+	driver := NewMockDriver()
+	watcher := NewAlertWatcher(NewAlertService(driver), time.Millisecond)
+	failures := make(chan error, 1)
+
+	watcher.OnError = func(err error) {
+		select {
+		case failures <- err:
+		default:
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher.Watch(ctx)
+
+	t.Run("it reports failed polls", func(t *testing.T) {
+		select {
+		case err := <-failures:
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for an error")
+		}
+	})
+}
+
+func TestAlertWatcher_Watch_Interval(t *testing.T) {
+	driver := NewMockDriver()
+	watcher := &AlertWatcher{Alerts: NewAlertService(driver)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := watcher.Watch(ctx)
+
+	t.Run("it doesn't panic without a positive interval", func(t *testing.T) {
+		cancel()
+
+		for range events {
+		}
+	})
+}
+
+func alertEventSummary(events []*AlertEvent) string {
+	summary := ""
+
+	for _, event := range events {
+		if len(summary) > 0 {
+			summary = summary + " "
+		}
+
+		summary = summary + string(event.Type) + ":" + event.Alert.ID
+	}
+
+	return summary
+}
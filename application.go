@@ -25,7 +25,7 @@ type ApplicationService struct {
 	Driver Driver
 }
 
-// NewApplicationService returns an AddressService configured to use the
+// NewApplicationService returns an ApplicationService configured to use the
 // provided Driver.
 func NewApplicationService(driver Driver) *ApplicationService {
 	return &ApplicationService{Driver: driver}
@@ -51,23 +51,269 @@ func (service *ApplicationService) ForEnvironment(environment *Environment, para
 	)
 }
 
+// Find returns the Application record identified by the given application id.
+// If there are errors in retrieving this information, an error is returned as
+// well.
+func (service *ApplicationService) Find(id string) (*Application, error) {
+	return service.unwrap(service.Driver.Get("applications/"+id, nil))
+}
+
+type applicationParams struct {
+	Name       string `json:"name,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Language   string `json:"language,omitempty"`
+	Type       string `json:"type,omitempty"`
+}
+
+// Create takes an Account and an Application, saving the Application on the
+// upstream API under the given Account. The Application's Name, Repository,
+// Language, and Type are sent along. If there are issues along the way, an
+// error is returned. Otherwise, the newly created Application is returned.
+func (service *ApplicationService) Create(account *Account, application *Application) (*Application, error) {
+	if account == nil || len(account.ID) == 0 {
+		return nil, fmt.Errorf("No valid account given")
+	}
+
+	if application == nil || len(application.Name) == 0 {
+		return nil, fmt.Errorf("An application requires a name")
+	}
+
+	if len(application.Repository) == 0 {
+		return nil, fmt.Errorf("An application requires a repository")
+	}
+
+	body, err := service.encode(application)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Post("accounts/"+account.ID+"/applications", nil, body),
+	)
+}
+
+// Update takes an Application, saving its Name, Repository, Language, and Type
+// on the upstream API. If there are issues along the way, an error is
+// returned. Otherwise, the updated Application is returned.
+func (service *ApplicationService) Update(application *Application) (*Application, error) {
+	if application == nil || application.ID == 0 {
+		return nil, fmt.Errorf("can't update an application without an ID")
+	}
+
+	body, err := service.encode(application)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Put(fmt.Sprintf("applications/%d", application.ID), nil, body),
+	)
+}
+
+// Destroy deletes the given Application from the upstream API. If there are
+// issues along the way, an error is returned.
+func (service *ApplicationService) Destroy(application *Application) error {
+	if application == nil || application.ID == 0 {
+		return fmt.Errorf("No valid application given")
+	}
+
+	response := service.Driver.Delete(
+		fmt.Sprintf("applications/%d", application.ID),
+		Params{},
+	)
+
+	if !response.Okay() {
+		return response.Error
+	}
+
+	return nil
+}
+
+// Attach associates the given Application with the given Environment so that
+// it can be deployed there. If there are issues along the way, an error is
+// returned.
+func (service *ApplicationService) Attach(application *Application, environment *Environment) error {
+	path, err := service.attachmentPath(application, environment)
+	if err != nil {
+		return err
+	}
+
+	response := service.Driver.Post(path, Params{}, nil)
+
+	if !response.Okay() {
+		return response.Error
+	}
+
+	return nil
+}
+
+// Detach removes the association between the given Application and the given
+// Environment. If there are issues along the way, an error is returned.
+func (service *ApplicationService) Detach(application *Application, environment *Environment) error {
+	path, err := service.attachmentPath(application, environment)
+	if err != nil {
+		return err
+	}
+
+	response := service.Driver.Delete(path, Params{})
+
+	if !response.Okay() {
+		return response.Error
+	}
+
+	return nil
+}
+
+// DeployKey returns the KeyPair that the given Application uses to access its
+// repository. If the Application has no such KeyPair, or its keypairs can't
+// be retrieved, an error is returned.
+func (service *ApplicationService) DeployKey(application *Application) (*KeyPair, error) {
+	if application == nil || application.ID == 0 {
+		return nil, fmt.Errorf("No valid application given")
+	}
+
+	keyPairs, err := service.keyPairs(application)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keyPairs) == 0 {
+		return nil, fmt.Errorf("Application %d has no deploy key", application.ID)
+	}
+
+	return keyPairs[0], nil
+}
+
+// SetupDeployKey ensures that the given Application has a deploy key. If the
+// Application already has one, it is returned. Otherwise, the upstream API is
+// asked to generate a new KeyPair for the Application, and that KeyPair is
+// returned. Nothing is generated if the Application's keypairs can't be
+// retrieved. If there are issues along the way, an error is returned.
+func (service *ApplicationService) SetupDeployKey(application *Application) (*KeyPair, error) {
+	if application == nil || application.ID == 0 {
+		return nil, fmt.Errorf("No valid application given")
+	}
+
+	existing, err := service.keyPairs(application)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(existing) > 0 {
+		return existing[0], nil
+	}
+
+	wrapper := struct {
+		KeyPair *KeyPair `json:"keypair,omitempty"`
+	}{KeyPair: &KeyPair{Name: application.Name + " deploy key"}}
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	response := service.Driver.Post(
+		fmt.Sprintf("applications/%d/keypairs", application.ID),
+		nil,
+		body,
+	)
+
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	created := struct {
+		KeyPair *KeyPair `json:"keypair,omitempty"`
+	}{}
+
+	err = json.Unmarshal(response.Pages[0], &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created.KeyPair, nil
+}
+
+func (service *ApplicationService) keyPairs(application *Application) ([]*KeyPair, error) {
+	return NewKeyPairService(service.Driver).fetch(
+		fmt.Sprintf("applications/%d/keypairs", application.ID),
+		nil,
+	)
+}
+
+func (service *ApplicationService) attachmentPath(application *Application, environment *Environment) (string, error) {
+	if application == nil || application.ID == 0 {
+		return "", fmt.Errorf("No valid application given")
+	}
+
+	if environment == nil || environment.ID == 0 {
+		return "", fmt.Errorf("No valid environment given")
+	}
+
+	return fmt.Sprintf(
+		"environments/%d/applications/%d",
+		environment.ID,
+		application.ID,
+	), nil
+}
+
+func (service *ApplicationService) encode(application *Application) ([]byte, error) {
+	wrapper := struct {
+		Application *applicationParams `json:"application,omitempty"`
+	}{
+		Application: &applicationParams{
+			Name:       application.Name,
+			Repository: application.Repository,
+			Language:   application.Language,
+			Type:       application.Type,
+		},
+	}
+
+	return json.Marshal(&wrapper)
+}
+
+func (service *ApplicationService) unwrap(response Response) (*Application, error) {
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		Application *Application `json:"application,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Application, nil
+}
+
 func (service *ApplicationService) collection(path string, params Params) []*Application {
+	applications, _ := service.fetch(path, params)
+
+	return applications
+}
+
+func (service *ApplicationService) fetch(path string, params Params) ([]*Application, error) {
 	applications := make([]*Application, 0)
 	response := service.Driver.Get(path, params)
 
-	if response.Okay() {
-		for _, page := range response.Pages {
-			wrapper := struct {
-				Applications []*Application `json:"applications,omitempty"`
-			}{}
+	if !response.Okay() {
+		return applications, response.Error
+	}
+
+	for _, page := range response.Pages {
+		wrapper := struct {
+			Applications []*Application `json:"applications,omitempty"`
+		}{}
 
-			if err := json.Unmarshal(page, &wrapper); err == nil {
-				applications = append(applications, wrapper.Applications...)
-			}
+		if err := json.Unmarshal(page, &wrapper); err == nil {
+			applications = append(applications, wrapper.Applications...)
 		}
 	}
 
-	return applications
+	return applications, nil
 }
 
 /*
@@ -2,7 +2,7 @@ package eygo
 
 import (
 	"encoding/json"
-	//"fmt"
+	"fmt"
 	"strconv"
 	"testing"
 )
@@ -176,6 +176,439 @@ func TestApplicationService_ForEnvironment(t *testing.T) {
 
 }
 
+func TestApplicationService_Find(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewApplicationService(driver)
+	application := &Application{ID: 1, Name: "Application 1"}
+	stubApplication(driver, application)
+
+	t.Run("for a known application", func(t *testing.T) {
+		result, err := service.Find("1")
+
+		t.Run("it is the requested application", func(t *testing.T) {
+			if result.ID != application.ID {
+				t.Errorf("Expected application 1, got application %d", result.ID)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("for an unknown application", func(t *testing.T) {
+		result, err := service.Find("2")
+
+		t.Run("it returns no application", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no application, got application %d", result.ID)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestApplicationService_Create(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewApplicationService(driver)
+	account := &Account{ID: "1", Name: "Account 1"}
+	application := &Application{
+		Name:       "myapp",
+		Repository: "git@github.com:example/myapp.git",
+		Language:   "ruby",
+		Type:       "rails4",
+	}
+
+	t.Run("with an invalid account", func(t *testing.T) {
+		driver.Reset()
+
+		_, err := service.Create(&Account{}, application)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("without a repository", func(t *testing.T) {
+		driver.Reset()
+
+		_, err := service.Create(account, &Application{Name: "myapp"})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it makes no API call", func(t *testing.T) {
+			if len(driver.Requests("post")) != 0 {
+				t.Errorf("Expected no post requests")
+			}
+		})
+	})
+
+	t.Run("when the creation is successful", func(t *testing.T) {
+		driver.Reset()
+
+		driver.AddResponse(
+			"post",
+			"accounts/"+account.ID+"/applications",
+			Response{
+				Pages: [][]byte{
+					[]byte(`{"application": {"id": 5, "name": "myapp", "repository": "git@github.com:example/myapp.git"}}`),
+				},
+			},
+		)
+
+		result, err := service.Create(account, application)
+
+		t.Run("it returns the new application", func(t *testing.T) {
+			if result == nil {
+				t.Fatalf("Expected an application")
+			}
+
+			if result.ID != 5 {
+				t.Errorf("Expected application 5, got %d", result.ID)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when the creation fails", func(t *testing.T) {
+		driver.Reset()
+
+		driver.AddResponse(
+			"post",
+			"accounts/"+account.ID+"/applications",
+			Response{Error: fmt.Errorf("Oh no!")},
+		)
+
+		result, err := service.Create(account, application)
+
+		t.Run("it returns no application", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no application, got %d", result.ID)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestApplicationService_Update(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewApplicationService(driver)
+	original := &Application{ID: 1, Name: "myapp", Repository: "git@example.com:new.git"}
+
+	t.Run("without an ID", func(t *testing.T) {
+		driver.Reset()
+
+		_, err := service.Update(&Application{Name: "myapp"})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when the update is successful", func(t *testing.T) {
+		driver.Reset()
+
+		driver.AddResponse(
+			"put",
+			"applications/1",
+			Response{
+				Pages: [][]byte{
+					[]byte(`{"application": {"id": 1, "name": "myapp", "repository": "git@example.com:new.git"}}`),
+				},
+			},
+		)
+
+		result, err := service.Update(original)
+
+		t.Run("it returns the updated application", func(t *testing.T) {
+			if result == nil {
+				t.Fatalf("Expected an application")
+			}
+
+			if result.Repository != original.Repository {
+				t.Errorf("Expected the application to have a new repository")
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when the update fails", func(t *testing.T) {
+		driver.Reset()
+
+		driver.AddResponse("put", "applications/1", Response{Error: fmt.Errorf("Oh no!")})
+
+		result, err := service.Update(original)
+
+		t.Run("it returns no application", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no application, got %d", result.ID)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestApplicationService_Destroy(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewApplicationService(driver)
+	application := &Application{ID: 1, Name: "myapp"}
+
+	t.Run("with an invalid application", func(t *testing.T) {
+		err := service.Destroy(&Application{})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when successful", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "applications/1", Response{Pages: [][]byte{[]byte(`true`)}})
+
+		err := service.Destroy(application)
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when unsuccessful", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "applications/1", Response{Error: fmt.Errorf("Oh no!")})
+
+		err := service.Destroy(application)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestApplicationService_Attach(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewApplicationService(driver)
+	application := &Application{ID: 2, Name: "myapp"}
+	environment := &Environment{ID: 1, Name: "production"}
+
+	t.Run("with an invalid environment", func(t *testing.T) {
+		err := service.Attach(application, &Environment{})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when successful", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("post", "environments/1/applications/2", Response{Pages: [][]byte{[]byte(`true`)}})
+
+		err := service.Attach(application, environment)
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when unsuccessful", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("post", "environments/1/applications/2", Response{Error: fmt.Errorf("Oh no!")})
+
+		err := service.Attach(application, environment)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestApplicationService_Detach(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewApplicationService(driver)
+	application := &Application{ID: 2, Name: "myapp"}
+	environment := &Environment{ID: 1, Name: "production"}
+
+	t.Run("with an invalid application", func(t *testing.T) {
+		err := service.Detach(nil, environment)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when successful", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "environments/1/applications/2", Response{Pages: [][]byte{[]byte(`true`)}})
+
+		err := service.Detach(application, environment)
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when unsuccessful", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "environments/1/applications/2", Response{Error: fmt.Errorf("Oh no!")})
+
+		err := service.Detach(application, environment)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestApplicationService_SetupDeployKey(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewApplicationService(driver)
+	application := &Application{ID: 2, Name: "myapp"}
+
+	t.Run("when the application already has a deploy key", func(t *testing.T) {
+		driver.Reset()
+		stubApplicationKeyPairs(driver, application, &KeyPair{ID: 7, Name: "existing"})
+
+		result, err := service.SetupDeployKey(application)
+
+		t.Run("it returns the existing key", func(t *testing.T) {
+			if result == nil || result.ID != 7 {
+				t.Errorf("Expected key pair 7, got %v", result)
+			}
+		})
+
+		t.Run("it does not generate a new key", func(t *testing.T) {
+			if len(driver.Requests("post")) != 0 {
+				t.Errorf("Expected no post requests")
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when the application has no deploy key", func(t *testing.T) {
+		driver.Reset()
+		stubApplicationKeyPairs(driver, application)
+		driver.AddResponse(
+			"post",
+			"applications/2/keypairs",
+			Response{
+				Pages: [][]byte{
+					[]byte(`{"keypair": {"id": 8, "name": "myapp deploy key", "public_key": "ssh-rsa AAAA"}}`),
+				},
+			},
+		)
+
+		result, err := service.SetupDeployKey(application)
+
+		t.Run("it returns the generated key", func(t *testing.T) {
+			if result == nil || result.ID != 8 {
+				t.Errorf("Expected key pair 8, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when the application's keypairs can't be retrieved", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("get", "applications/2/keypairs", Response{Error: fmt.Errorf("Oh no!")})
+
+		_, err := service.SetupDeployKey(application)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it does not generate a new key", func(t *testing.T) {
+			if len(driver.Requests("post")) != 0 {
+				t.Errorf("Expected no post requests")
+			}
+		})
+	})
+
+	t.Run("when generation fails", func(t *testing.T) {
+		driver.Reset()
+		stubApplicationKeyPairs(driver, application)
+		driver.AddResponse("post", "applications/2/keypairs", Response{Error: fmt.Errorf("Oh no!")})
+
+		result, err := service.SetupDeployKey(application)
+
+		t.Run("it returns no key", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no key pair, got %d", result.ID)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
 func stubApplications(driver *MockDriver, applications ...*Application) {
 	pages := make([][]byte, 0)
 
@@ -214,3 +647,16 @@ func stubEnvironmentApplications(driver *MockDriver, environment *Environment, a
 		driver.AddResponse("get", "environments/"+strconv.Itoa(environment.ID)+"/applications", Response{Pages: pages})
 	}
 }
+
+func stubApplication(driver *MockDriver, application *Application) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		Application *Application `json:"application,omitempty"`
+	}{Application: application}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "applications/"+strconv.Itoa(application.ID), Response{Pages: pages})
+	}
+}
@@ -0,0 +1,218 @@
+package eygo
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AutoScalingAlarm is a data structure that models an alarm that triggers an
+// Engine Yard auto scaling policy.
+type AutoScalingAlarm struct {
+	ID                   string  `json:"id,omitempty"`
+	Name                 string  `json:"name,omitempty"`
+	AutoScalingPolicyURL string  `json:"auto_scaling_policy,omitempty"`
+	Aggregation          string  `json:"aggregation_type,omitempty"`
+	MetricType           string  `json:"metric_type,omitempty"`
+	Operand              string  `json:"operand,omitempty"`
+	Trigger              float64 `json:"trigger_value,omitempty"`
+	NumberOfPeriods      int     `json:"number_of_periods,omitempty"`
+	PeriodLength         int     `json:"period_length,omitempty"`
+	CreatedAt            string  `json:"created_at,omitempty"`
+	UpdatedAt            string  `json:"updated_at,omitempty"`
+}
+
+// Validate checks that the given AutoScalingAlarm has a name, knows what it
+// is measuring and how to compare it, and has a positive evaluation window.
+func (alarm *AutoScalingAlarm) Validate() error {
+	if len(alarm.Name) == 0 {
+		return fmt.Errorf("An auto scaling alarm requires a name")
+	}
+
+	if len(alarm.MetricType) == 0 || len(alarm.Operand) == 0 {
+		return fmt.Errorf("An auto scaling alarm requires a metric type and an operand")
+	}
+
+	if alarm.NumberOfPeriods < 1 || alarm.PeriodLength < 1 {
+		return fmt.Errorf("An auto scaling alarm requires a positive number of periods and period length")
+	}
+
+	return nil
+}
+
+// AutoScalingAlarmService is a repository one can use to retrieve and save
+// AutoScalingAlarm records on the API.
+type AutoScalingAlarmService struct {
+	Driver Driver
+}
+
+// NewAutoScalingAlarmService returns an AutoScalingAlarmService configured to
+// use the provided Driver.
+func NewAutoScalingAlarmService(driver Driver) *AutoScalingAlarmService {
+	return &AutoScalingAlarmService{Driver: driver}
+}
+
+// ForAutoScalingPolicy returns an array of AutoScalingAlarms that are both
+// associated with the given AutoScalingPolicy and matching the given Params.
+func (service *AutoScalingAlarmService) ForAutoScalingPolicy(policy *AutoScalingPolicy, params Params) []*AutoScalingAlarm {
+	return service.collection(
+		"auto_scaling_policies/"+policy.ID+"/auto_scaling_alarms",
+		params,
+	)
+}
+
+// Find returns the AutoScalingAlarm record identified by the given alarm id.
+// If there are errors in retrieving this information, an error is returned
+// as well.
+func (service *AutoScalingAlarmService) Find(id string) (*AutoScalingAlarm, error) {
+	return service.unwrap(service.Driver.Get("auto_scaling_alarms/"+id, nil))
+}
+
+// Create takes an AutoScalingPolicy and an AutoScalingAlarm, saving the alarm
+// on the upstream API for the given policy. If there are issues along the
+// way, an error is returned. Otherwise, the newly created AutoScalingAlarm is
+// returned.
+func (service *AutoScalingAlarmService) Create(policy *AutoScalingPolicy, alarm *AutoScalingAlarm) (*AutoScalingAlarm, error) {
+	if policy == nil || len(policy.ID) == 0 {
+		return nil, fmt.Errorf("No valid auto scaling policy given")
+	}
+
+	if alarm == nil {
+		return nil, fmt.Errorf("No auto scaling alarm given")
+	}
+
+	if err := alarm.Validate(); err != nil {
+		return nil, err
+	}
+
+	body, err := service.encode(alarm)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Post(
+			"auto_scaling_policies/"+policy.ID+"/auto_scaling_alarms",
+			nil,
+			body,
+		),
+	)
+}
+
+// Update saves the given AutoScalingAlarm on the upstream API. If there are
+// issues along the way, an error is returned. Otherwise, the updated
+// AutoScalingAlarm is returned.
+func (service *AutoScalingAlarmService) Update(alarm *AutoScalingAlarm) (*AutoScalingAlarm, error) {
+	if alarm == nil || len(alarm.ID) == 0 {
+		return nil, fmt.Errorf("can't update an auto scaling alarm without an ID")
+	}
+
+	if err := alarm.Validate(); err != nil {
+		return nil, err
+	}
+
+	body, err := service.encode(alarm)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Put("auto_scaling_alarms/"+alarm.ID, nil, body),
+	)
+}
+
+// Destroy deletes the given AutoScalingAlarm from the upstream API. If there
+// are issues along the way, an error is returned.
+func (service *AutoScalingAlarmService) Destroy(alarm *AutoScalingAlarm) error {
+	if alarm == nil || len(alarm.ID) == 0 {
+		return fmt.Errorf("No valid auto scaling alarm given")
+	}
+
+	response := service.Driver.Delete("auto_scaling_alarms/"+alarm.ID, Params{})
+	if !response.Okay() {
+		return response.Error
+	}
+
+	return nil
+}
+
+// autoScalingAlarmParams is the body sent when an alarm is saved. Its numeric
+// fields are always sent, so that they can be set to their zero values.
+type autoScalingAlarmParams struct {
+	Name            string  `json:"name,omitempty"`
+	Aggregation     string  `json:"aggregation_type,omitempty"`
+	MetricType      string  `json:"metric_type,omitempty"`
+	Operand         string  `json:"operand,omitempty"`
+	Trigger         float64 `json:"trigger_value"`
+	NumberOfPeriods int     `json:"number_of_periods"`
+	PeriodLength    int     `json:"period_length"`
+}
+
+func (service *AutoScalingAlarmService) encode(alarm *AutoScalingAlarm) ([]byte, error) {
+	wrapper := struct {
+		AutoScalingAlarm *autoScalingAlarmParams `json:"auto_scaling_alarm"`
+	}{
+		AutoScalingAlarm: &autoScalingAlarmParams{
+			Name:            alarm.Name,
+			Aggregation:     alarm.Aggregation,
+			MetricType:      alarm.MetricType,
+			Operand:         alarm.Operand,
+			Trigger:         alarm.Trigger,
+			NumberOfPeriods: alarm.NumberOfPeriods,
+			PeriodLength:    alarm.PeriodLength,
+		},
+	}
+
+	return json.Marshal(&wrapper)
+}
+
+func (service *AutoScalingAlarmService) unwrap(response Response) (*AutoScalingAlarm, error) {
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		AutoScalingAlarm *AutoScalingAlarm `json:"auto_scaling_alarm,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.AutoScalingAlarm, nil
+}
+
+func (service *AutoScalingAlarmService) collection(path string, params Params) []*AutoScalingAlarm {
+	alarms := make([]*AutoScalingAlarm, 0)
+	response := service.Driver.Get(path, params)
+
+	if response.Okay() {
+		for _, page := range response.Pages {
+			wrapper := struct {
+				AutoScalingAlarms []*AutoScalingAlarm `json:"auto_scaling_alarms,omitempty"`
+			}{}
+
+			if err := json.Unmarshal(page, &wrapper); err == nil {
+				alarms = append(alarms, wrapper.AutoScalingAlarms...)
+			}
+		}
+	}
+
+	return alarms
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
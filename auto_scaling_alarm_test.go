@@ -0,0 +1,232 @@
+package eygo
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestNewAutoScalingAlarmService(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAutoScalingAlarmService(driver)
+
+	t.Run("it is configured with the given driver", func(t *testing.T) {
+		if service.Driver != driver {
+			t.Errorf("Expected the service to use the given driver")
+		}
+	})
+}
+
+func TestAutoScalingAlarmService_ForAutoScalingPolicy(t *testing.T) {
+	policy := &AutoScalingPolicy{ID: "5"}
+	driver := NewMockDriver()
+	service := NewAutoScalingAlarmService(driver)
+
+	t.Run("when there are matching alarms", func(t *testing.T) {
+		stubAutoScalingPolicyAlarms(driver, policy, &AutoScalingAlarm{ID: "1"}, &AutoScalingAlarm{ID: "2"})
+
+		if all := service.ForAutoScalingPolicy(policy, nil); len(all) != 2 {
+			t.Errorf("Expected 2 alarms, got %d", len(all))
+		}
+	})
+
+	t.Run("when there are no matching alarms", func(t *testing.T) {
+		driver.Reset()
+
+		if all := service.ForAutoScalingPolicy(policy, nil); len(all) != 0 {
+			t.Errorf("Expected 0 alarms, got %d", len(all))
+		}
+	})
+}
+
+func TestAutoScalingAlarmService_Find(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAutoScalingAlarmService(driver)
+	stubAutoScalingAlarm(driver, &AutoScalingAlarm{ID: "1"})
+
+	t.Run("for a known alarm", func(t *testing.T) {
+		result, err := service.Find("1")
+
+		if result == nil || result.ID != "1" {
+			t.Errorf("Expected alarm 1, got %v", result)
+		}
+
+		if err != nil {
+			t.Errorf("Expected no error")
+		}
+	})
+
+	t.Run("for an unknown alarm", func(t *testing.T) {
+		if _, err := service.Find("2"); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestAutoScalingAlarm_Validate(t *testing.T) {
+	valid := &AutoScalingAlarm{Name: "busy", MetricType: "cpu", Operand: "gt", NumberOfPeriods: 2, PeriodLength: 60}
+
+	t.Run("it accepts a complete alarm", func(t *testing.T) {
+		if err := valid.Validate(); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	invalid := map[string]*AutoScalingAlarm{
+		"unnamed":       {MetricType: "cpu", Operand: "gt", NumberOfPeriods: 2, PeriodLength: 60},
+		"metricless":    {Name: "busy", Operand: "gt", NumberOfPeriods: 2, PeriodLength: 60},
+		"operandless":   {Name: "busy", MetricType: "cpu", NumberOfPeriods: 2, PeriodLength: 60},
+		"periodless":    {Name: "busy", MetricType: "cpu", Operand: "gt", PeriodLength: 60},
+		"instantaneous": {Name: "busy", MetricType: "cpu", Operand: "gt", NumberOfPeriods: 2},
+	}
+
+	for name, alarm := range invalid {
+		t.Run("it rejects an alarm that is "+name, func(t *testing.T) {
+			if err := alarm.Validate(); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestAutoScalingAlarmService_Create(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAutoScalingAlarmService(driver)
+	policy := &AutoScalingPolicy{ID: "5"}
+	alarm := &AutoScalingAlarm{Name: "busy", MetricType: "cpu", Operand: "gt", Trigger: 80, NumberOfPeriods: 2, PeriodLength: 60}
+
+	t.Run("without a policy", func(t *testing.T) {
+		if _, err := service.Create(&AutoScalingPolicy{}, alarm); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with a valid alarm", func(t *testing.T) {
+		driver.AddResponse(
+			"post",
+			"auto_scaling_policies/5/auto_scaling_alarms",
+			Response{Pages: [][]byte{[]byte(`{"auto_scaling_alarm": {"id": "9", "name": "busy"}}`)}},
+		)
+
+		result, err := service.Create(policy, alarm)
+
+		t.Run("it sends the alarm settings", func(t *testing.T) {
+			bodies := driver.Bodies("post")
+			expected := `{"auto_scaling_alarm":{"name":"busy","metric_type":"cpu","operand":"gt","trigger_value":80,"number_of_periods":2,"period_length":60}}`
+
+			if len(bodies) != 1 || string(bodies[0]) != expected {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns the new alarm", func(t *testing.T) {
+			if result == nil || result.ID != "9" {
+				t.Errorf("Expected alarm 9, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestAutoScalingAlarmService_Update(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAutoScalingAlarmService(driver)
+
+	t.Run("without an ID", func(t *testing.T) {
+		if _, err := service.Update(&AutoScalingAlarm{Name: "busy"}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with a valid alarm", func(t *testing.T) {
+		driver.AddResponse(
+			"put",
+			"auto_scaling_alarms/9",
+			Response{Pages: [][]byte{[]byte(`{"auto_scaling_alarm": {"id": "9", "trigger_value": 90}}`)}},
+		)
+
+		result, err := service.Update(
+			&AutoScalingAlarm{ID: "9", Name: "busy", MetricType: "cpu", Operand: "gt", Trigger: 90, NumberOfPeriods: 2, PeriodLength: 60},
+		)
+
+		if result == nil || result.Trigger != 90 {
+			t.Errorf("Expected the updated alarm, got %v", result)
+		}
+
+		if err != nil {
+			t.Errorf("Expected no error")
+		}
+	})
+
+	t.Run("with a zero trigger value", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse(
+			"put",
+			"auto_scaling_alarms/9",
+			Response{Pages: [][]byte{[]byte(`{"auto_scaling_alarm": {"id": "9"}}`)}},
+		)
+
+		service.Update(
+			&AutoScalingAlarm{ID: "9", Name: "idle", MetricType: "cpu", Operand: "lt", Trigger: 0, NumberOfPeriods: 2, PeriodLength: 60},
+		)
+
+		expected := `{"auto_scaling_alarm":{"name":"idle","metric_type":"cpu","operand":"lt","trigger_value":0,"number_of_periods":2,"period_length":60}}`
+
+		if bodies := driver.Bodies("put"); len(bodies) != 1 || string(bodies[0]) != expected {
+			t.Errorf("Unexpected request bodies: %s", bodies)
+		}
+	})
+}
+
+func TestAutoScalingAlarmService_Destroy(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAutoScalingAlarmService(driver)
+
+	t.Run("when the API accepts the deletion", func(t *testing.T) {
+		driver.AddResponse("delete", "auto_scaling_alarms/9", Response{})
+
+		if err := service.Destroy(&AutoScalingAlarm{ID: "9"}); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("when the API rejects the deletion", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "auto_scaling_alarms/9", Response{Error: fmt.Errorf("Oh no!")})
+
+		if err := service.Destroy(&AutoScalingAlarm{ID: "9"}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func stubAutoScalingPolicyAlarms(driver *MockDriver, policy *AutoScalingPolicy, alarms ...*AutoScalingAlarm) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		AutoScalingAlarms []*AutoScalingAlarm `json:"auto_scaling_alarms,omitempty"`
+	}{AutoScalingAlarms: alarms}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "auto_scaling_policies/"+policy.ID+"/auto_scaling_alarms", Response{Pages: pages})
+	}
+}
+
+func stubAutoScalingAlarm(driver *MockDriver, alarm *AutoScalingAlarm) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		AutoScalingAlarm *AutoScalingAlarm `json:"auto_scaling_alarm,omitempty"`
+	}{AutoScalingAlarm: alarm}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "auto_scaling_alarms/"+alarm.ID, Response{Pages: pages})
+	}
+}
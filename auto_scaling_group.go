@@ -2,6 +2,7 @@ package eygo
 
 import (
 	"encoding/json"
+	"fmt"
 )
 
 // AutoScalingGroup is a data structure that models an Engine Yard autoscalinggroup.
@@ -16,6 +17,38 @@ type AutoScalingGroup struct {
 	LocationID      string `json:"location_id,omitempty"`
 }
 
+// Validate checks that the sizes of the given AutoScalingGroup are sane: none
+// of them may be negative, the maximum size must be at least one and no less
+// than the minimum size, and the desired capacity must fall between the two.
+func (group *AutoScalingGroup) Validate() error {
+	if group.MinimumSize < 0 {
+		return fmt.Errorf("Minimum size can't be negative")
+	}
+
+	if group.MaximumSize < 1 {
+		return fmt.Errorf("Maximum size must be at least 1")
+	}
+
+	if group.MaximumSize < group.MinimumSize {
+		return fmt.Errorf(
+			"Maximum size (%d) can't be less than minimum size (%d)",
+			group.MaximumSize,
+			group.MinimumSize,
+		)
+	}
+
+	if group.DesiredCapacity < group.MinimumSize || group.DesiredCapacity > group.MaximumSize {
+		return fmt.Errorf(
+			"Desired capacity (%d) must be between %d and %d",
+			group.DesiredCapacity,
+			group.MinimumSize,
+			group.MaximumSize,
+		)
+	}
+
+	return nil
+}
+
 // AutoScalingGroupService is a repository one can use to retrieve and save AutoScalingGroup
 // records on the API.
 type AutoScalingGroupService struct {
@@ -36,21 +69,132 @@ func (service *AutoScalingGroupService) All(params Params) []*AutoScalingGroup {
 // Find returns the AutoScalingGroup record identified by the given autoscalinggroup id. If there
 // are errors in retrieving this information, an error is returned as well.
 func (service *AutoScalingGroupService) Find(id string) (*AutoScalingGroup, error) {
-	response := service.Driver.Get("auto_scaling_groups/"+id, nil)
-	if response.Okay() {
-		wrapper := struct {
-			AutoScalingGroup *AutoScalingGroup `json:"auto_scaling_group,omitempty"`
-		}{}
+	return service.unwrap(service.Driver.Get("auto_scaling_groups/"+id, nil))
+}
 
-		err := json.Unmarshal(response.Pages[0], &wrapper)
-		if err != nil {
-			return nil, err
-		}
+// ForEnvironment returns the AutoScalingGroup associated with the given
+// Environment. If the Environment has no such group, or if there are errors in
+// retrieving this information, an error is returned.
+func (service *AutoScalingGroupService) ForEnvironment(environment *Environment) (*AutoScalingGroup, error) {
+	if environment == nil {
+		return nil, fmt.Errorf("No valid environment given")
+	}
+
+	if len(environment.AutoScalingGroupURL) == 0 {
+		return nil, fmt.Errorf("Environment %s has no auto scaling group", environment.Name)
+	}
+
+	return service.unwrap(
+		service.Driver.Get(pathFor(environment.AutoScalingGroupURL), nil),
+	)
+}
+
+type autoScalingGroupParams struct {
+	MinimumSize     int `json:"minimum_size"`
+	MaximumSize     int `json:"maximum_size"`
+	DesiredCapacity int `json:"desired_capacity"`
+}
+
+func newAutoScalingGroupParams(group *AutoScalingGroup) *autoScalingGroupParams {
+	return &autoScalingGroupParams{
+		MinimumSize:     group.MinimumSize,
+		MaximumSize:     group.MaximumSize,
+		DesiredCapacity: group.DesiredCapacity,
+	}
+}
+
+// Create takes an Environment and an AutoScalingGroup, saving the group on
+// the upstream API for the given Environment. The group's sizes are validated
+// before anything is sent. If there are issues along the way, an error is
+// returned. Otherwise, the newly created AutoScalingGroup is returned.
+func (service *AutoScalingGroupService) Create(environment *Environment, group *AutoScalingGroup) (*AutoScalingGroup, error) {
+	if environment == nil || environment.ID == 0 {
+		return nil, fmt.Errorf("No valid environment given")
+	}
+
+	if group == nil {
+		return nil, fmt.Errorf("No auto scaling group given")
+	}
+
+	if err := group.Validate(); err != nil {
+		return nil, err
+	}
+
+	body, err := service.encode(newAutoScalingGroupParams(group))
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Post(
+			fmt.Sprintf("environments/%d/auto_scaling_groups", environment.ID),
+			nil,
+			body,
+		),
+	)
+}
+
+// Update saves the MinimumSize, MaximumSize, and DesiredCapacity of the given
+// AutoScalingGroup on the upstream API. The sizes are validated before
+// anything is sent. If there are issues along the way, an error is returned.
+// Otherwise, the updated AutoScalingGroup is returned.
+func (service *AutoScalingGroupService) Update(group *AutoScalingGroup) (*AutoScalingGroup, error) {
+	if group == nil || len(group.ID) == 0 {
+		return nil, fmt.Errorf("can't update an auto scaling group without an ID")
+	}
+
+	if err := group.Validate(); err != nil {
+		return nil, err
+	}
+
+	body, err := service.encode(newAutoScalingGroupParams(group))
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Put("auto_scaling_groups/"+group.ID, nil, body),
+	)
+}
+
+// Destroy deletes the given AutoScalingGroup from the upstream API. If there
+// are issues along the way, an error is returned.
+func (service *AutoScalingGroupService) Destroy(group *AutoScalingGroup) error {
+	if group == nil || len(group.ID) == 0 {
+		return fmt.Errorf("No valid auto scaling group given")
+	}
+
+	response := service.Driver.Delete("auto_scaling_groups/"+group.ID, Params{})
+	if !response.Okay() {
+		return response.Error
+	}
+
+	return nil
+}
+
+func (service *AutoScalingGroupService) encode(params *autoScalingGroupParams) ([]byte, error) {
+	wrapper := struct {
+		AutoScalingGroup *autoScalingGroupParams `json:"auto_scaling_group,omitempty"`
+	}{AutoScalingGroup: params}
+
+	return json.Marshal(&wrapper)
+}
+
+func (service *AutoScalingGroupService) unwrap(response Response) (*AutoScalingGroup, error) {
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		AutoScalingGroup *AutoScalingGroup `json:"auto_scaling_group,omitempty"`
+	}{}
 
-		return wrapper.AutoScalingGroup, nil
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, response.Error
+	return wrapper.AutoScalingGroup, nil
 }
 
 func (service *AutoScalingGroupService) collection(path string, params Params) []*AutoScalingGroup {
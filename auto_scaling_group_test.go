@@ -2,6 +2,7 @@ package eygo
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 )
 
@@ -107,6 +108,196 @@ func TestAutoScalingGroupService_Find(t *testing.T) {
 	})
 }
 
+func TestAutoScalingGroupService_ForEnvironment(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAutoScalingGroupService(driver)
+	stubAutoScalingGroup(driver, &AutoScalingGroup{ID: "1"})
+
+	t.Run("for an environment with a group", func(t *testing.T) {
+		environment := &Environment{
+			ID:                  1,
+			AutoScalingGroupURL: "https://api.engineyard.com/auto_scaling_groups/1",
+		}
+
+		result, err := service.ForEnvironment(environment)
+
+		t.Run("it is the environment's group", func(t *testing.T) {
+			if result == nil || result.ID != "1" {
+				t.Errorf("Expected autoscalinggroup 1, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("for an environment without a group", func(t *testing.T) {
+		result, err := service.ForEnvironment(&Environment{ID: 2})
+
+		t.Run("it returns no group", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no autoscalinggroup, got %s", result.ID)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestAutoScalingGroup_Validate(t *testing.T) {
+	valid := []*AutoScalingGroup{
+		{MinimumSize: 0, MaximumSize: 1, DesiredCapacity: 0},
+		{MinimumSize: 1, MaximumSize: 3, DesiredCapacity: 2},
+		{MinimumSize: 2, MaximumSize: 2, DesiredCapacity: 2},
+	}
+
+	invalid := []*AutoScalingGroup{
+		{MinimumSize: -1, MaximumSize: 1, DesiredCapacity: 0},
+		{MinimumSize: 0, MaximumSize: 0, DesiredCapacity: 0},
+		{MinimumSize: 3, MaximumSize: 2, DesiredCapacity: 2},
+		{MinimumSize: 1, MaximumSize: 3, DesiredCapacity: 0},
+		{MinimumSize: 1, MaximumSize: 3, DesiredCapacity: 4},
+	}
+
+	for _, group := range valid {
+		t.Run(fmt.Sprintf("it accepts %d/%d/%d", group.MinimumSize, group.DesiredCapacity, group.MaximumSize), func(t *testing.T) {
+			if err := group.Validate(); err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+	}
+
+	for _, group := range invalid {
+		t.Run(fmt.Sprintf("it rejects %d/%d/%d", group.MinimumSize, group.DesiredCapacity, group.MaximumSize), func(t *testing.T) {
+			if err := group.Validate(); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestAutoScalingGroupService_Create(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAutoScalingGroupService(driver)
+	environment := &Environment{ID: 1}
+
+	t.Run("with invalid sizes", func(t *testing.T) {
+		_, err := service.Create(environment, &AutoScalingGroup{MinimumSize: 2, MaximumSize: 1})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it doesn't contact the API", func(t *testing.T) {
+			if len(driver.Requests("post")) != 0 {
+				t.Errorf("Expected no post requests")
+			}
+		})
+	})
+
+	t.Run("with valid sizes", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse(
+			"post",
+			"environments/1/auto_scaling_groups",
+			Response{Pages: [][]byte{[]byte(`{"auto_scaling_group": {"id": "1", "minimum_size": 0, "maximum_size": 2}}`)}},
+		)
+
+		result, err := service.Create(environment, &AutoScalingGroup{MaximumSize: 2})
+
+		t.Run("it sends every size", func(t *testing.T) {
+			bodies := driver.Bodies("post")
+			expected := `{"auto_scaling_group":{"minimum_size":0,"maximum_size":2,"desired_capacity":0}}`
+
+			if len(bodies) != 1 || string(bodies[0]) != expected {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns the new group", func(t *testing.T) {
+			if result == nil || result.ID != "1" {
+				t.Errorf("Expected autoscalinggroup 1, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestAutoScalingGroupService_Update(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAutoScalingGroupService(driver)
+
+	t.Run("without an ID", func(t *testing.T) {
+		if _, err := service.Update(&AutoScalingGroup{MaximumSize: 1}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with invalid sizes", func(t *testing.T) {
+		if _, err := service.Update(&AutoScalingGroup{ID: "1", MaximumSize: 1, DesiredCapacity: 5}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with valid sizes", func(t *testing.T) {
+		driver.AddResponse(
+			"put",
+			"auto_scaling_groups/1",
+			Response{Pages: [][]byte{[]byte(`{"auto_scaling_group": {"id": "1", "minimum_size": 1, "maximum_size": 4, "desired_capacity": 2}}`)}},
+		)
+
+		result, err := service.Update(&AutoScalingGroup{ID: "1", MinimumSize: 1, MaximumSize: 4, DesiredCapacity: 2})
+
+		t.Run("it returns the updated group", func(t *testing.T) {
+			if result == nil || result.MaximumSize != 4 {
+				t.Errorf("Expected the updated autoscalinggroup, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestAutoScalingGroupService_Destroy(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAutoScalingGroupService(driver)
+
+	t.Run("when the API accepts the deletion", func(t *testing.T) {
+		driver.AddResponse("delete", "auto_scaling_groups/1", Response{})
+
+		if err := service.Destroy(&AutoScalingGroup{ID: "1"}); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("when the API rejects the deletion", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "auto_scaling_groups/1", Response{Error: fmt.Errorf("Oh no!")})
+
+		if err := service.Destroy(&AutoScalingGroup{ID: "1"}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
 func stubAutoScalingGroups(driver *MockDriver, autoscalinggroups ...*AutoScalingGroup) {
 	pages := make([][]byte, 0)
 
@@ -0,0 +1,247 @@
+package eygo
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// SimplePolicy is an AutoScalingPolicy type that adjusts the group by a
+	// fixed amount whenever one of its alarms is triggered.
+	SimplePolicy = "simple"
+
+	// StepPolicy is an AutoScalingPolicy type that adjusts the group by an
+	// amount that depends on how far its alarm threshold has been exceeded.
+	StepPolicy = "step"
+
+	// TargetPolicy is an AutoScalingPolicy type that adjusts the group to keep
+	// a metric near a target value.
+	TargetPolicy = "target"
+)
+
+// AutoScalingPolicy is a data structure that models a scaling policy for an
+// Engine Yard auto scaling group.
+type AutoScalingPolicy struct {
+	ID                  string  `json:"id,omitempty"`
+	Name                string  `json:"name,omitempty"`
+	Type                string  `json:"type,omitempty"`
+	AutoScalingGroupURL string  `json:"auto_scaling_group,omitempty"`
+	ActionType          string  `json:"action_type,omitempty"`
+	ActionUnit          string  `json:"action_unit,omitempty"`
+	ActionValue         int     `json:"action_value,omitempty"`
+	Cooldown            int     `json:"cooldown,omitempty"`
+	EstimatedWarmup     int     `json:"estimated_warmup,omitempty"`
+	MetricType          string  `json:"metric_type,omitempty"`
+	TargetValue         float64 `json:"target_value,omitempty"`
+	DisableScaleIn      bool    `json:"disable_scale_in,omitempty"`
+	CreatedAt           string  `json:"created_at,omitempty"`
+	UpdatedAt           string  `json:"updated_at,omitempty"`
+}
+
+// Validate checks that the given AutoScalingPolicy has a name and a known
+// type, and that target policies know what they're targeting.
+func (policy *AutoScalingPolicy) Validate() error {
+	if len(policy.Name) == 0 {
+		return fmt.Errorf("An auto scaling policy requires a name")
+	}
+
+	switch policy.Type {
+	case SimplePolicy, StepPolicy:
+		if len(policy.ActionType) == 0 {
+			return fmt.Errorf("A %s policy requires an action type", policy.Type)
+		}
+	case TargetPolicy:
+		if len(policy.MetricType) == 0 || policy.TargetValue <= 0 {
+			return fmt.Errorf("A target policy requires a metric type and a positive target value")
+		}
+	default:
+		return fmt.Errorf("Unknown auto scaling policy type: %s", policy.Type)
+	}
+
+	return nil
+}
+
+// AutoScalingPolicyService is a repository one can use to retrieve and save
+// AutoScalingPolicy records on the API.
+type AutoScalingPolicyService struct {
+	Driver Driver
+}
+
+// NewAutoScalingPolicyService returns an AutoScalingPolicyService configured
+// to use the provided Driver.
+func NewAutoScalingPolicyService(driver Driver) *AutoScalingPolicyService {
+	return &AutoScalingPolicyService{Driver: driver}
+}
+
+// ForAutoScalingGroup returns an array of AutoScalingPolicies that are both
+// associated with the given AutoScalingGroup and matching the given Params.
+func (service *AutoScalingPolicyService) ForAutoScalingGroup(group *AutoScalingGroup, params Params) []*AutoScalingPolicy {
+	return service.collection(
+		"auto_scaling_groups/"+group.ID+"/auto_scaling_policies",
+		params,
+	)
+}
+
+// Find returns the AutoScalingPolicy record identified by the given policy
+// id. If there are errors in retrieving this information, an error is
+// returned as well.
+func (service *AutoScalingPolicyService) Find(id string) (*AutoScalingPolicy, error) {
+	return service.unwrap(service.Driver.Get("auto_scaling_policies/"+id, nil))
+}
+
+// Create takes an AutoScalingGroup and an AutoScalingPolicy, saving the
+// policy on the upstream API for the given group. If there are issues along
+// the way, an error is returned. Otherwise, the newly created
+// AutoScalingPolicy is returned.
+func (service *AutoScalingPolicyService) Create(group *AutoScalingGroup, policy *AutoScalingPolicy) (*AutoScalingPolicy, error) {
+	if group == nil || len(group.ID) == 0 {
+		return nil, fmt.Errorf("No valid auto scaling group given")
+	}
+
+	if policy == nil {
+		return nil, fmt.Errorf("No auto scaling policy given")
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	body, err := service.encode(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Post(
+			"auto_scaling_groups/"+group.ID+"/auto_scaling_policies",
+			nil,
+			body,
+		),
+	)
+}
+
+// Update saves the given AutoScalingPolicy on the upstream API. If there are
+// issues along the way, an error is returned. Otherwise, the updated
+// AutoScalingPolicy is returned.
+func (service *AutoScalingPolicyService) Update(policy *AutoScalingPolicy) (*AutoScalingPolicy, error) {
+	if policy == nil || len(policy.ID) == 0 {
+		return nil, fmt.Errorf("can't update an auto scaling policy without an ID")
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	body, err := service.encode(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Put("auto_scaling_policies/"+policy.ID, nil, body),
+	)
+}
+
+// Destroy deletes the given AutoScalingPolicy from the upstream API. If there
+// are issues along the way, an error is returned.
+func (service *AutoScalingPolicyService) Destroy(policy *AutoScalingPolicy) error {
+	if policy == nil || len(policy.ID) == 0 {
+		return fmt.Errorf("No valid auto scaling policy given")
+	}
+
+	response := service.Driver.Delete("auto_scaling_policies/"+policy.ID, Params{})
+	if !response.Okay() {
+		return response.Error
+	}
+
+	return nil
+}
+
+// autoScalingPolicyParams is the body sent when a policy is saved. Its
+// numeric and boolean fields are always sent, so that they can be set to
+// their zero values.
+type autoScalingPolicyParams struct {
+	Name            string  `json:"name,omitempty"`
+	Type            string  `json:"type,omitempty"`
+	ActionType      string  `json:"action_type,omitempty"`
+	ActionUnit      string  `json:"action_unit,omitempty"`
+	ActionValue     int     `json:"action_value"`
+	Cooldown        int     `json:"cooldown"`
+	EstimatedWarmup int     `json:"estimated_warmup"`
+	MetricType      string  `json:"metric_type,omitempty"`
+	TargetValue     float64 `json:"target_value"`
+	DisableScaleIn  bool    `json:"disable_scale_in"`
+}
+
+func (service *AutoScalingPolicyService) encode(policy *AutoScalingPolicy) ([]byte, error) {
+	wrapper := struct {
+		AutoScalingPolicy *autoScalingPolicyParams `json:"auto_scaling_policy"`
+	}{
+		AutoScalingPolicy: &autoScalingPolicyParams{
+			Name:            policy.Name,
+			Type:            policy.Type,
+			ActionType:      policy.ActionType,
+			ActionUnit:      policy.ActionUnit,
+			ActionValue:     policy.ActionValue,
+			Cooldown:        policy.Cooldown,
+			EstimatedWarmup: policy.EstimatedWarmup,
+			MetricType:      policy.MetricType,
+			TargetValue:     policy.TargetValue,
+			DisableScaleIn:  policy.DisableScaleIn,
+		},
+	}
+
+	return json.Marshal(&wrapper)
+}
+
+func (service *AutoScalingPolicyService) unwrap(response Response) (*AutoScalingPolicy, error) {
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		AutoScalingPolicy *AutoScalingPolicy `json:"auto_scaling_policy,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.AutoScalingPolicy, nil
+}
+
+func (service *AutoScalingPolicyService) collection(path string, params Params) []*AutoScalingPolicy {
+	policies := make([]*AutoScalingPolicy, 0)
+	response := service.Driver.Get(path, params)
+
+	if response.Okay() {
+		for _, page := range response.Pages {
+			wrapper := struct {
+				AutoScalingPolicies []*AutoScalingPolicy `json:"auto_scaling_policies,omitempty"`
+			}{}
+
+			if err := json.Unmarshal(page, &wrapper); err == nil {
+				policies = append(policies, wrapper.AutoScalingPolicies...)
+			}
+		}
+	}
+
+	return policies
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
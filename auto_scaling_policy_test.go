@@ -0,0 +1,262 @@
+package eygo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNewAutoScalingPolicyService(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAutoScalingPolicyService(driver)
+
+	t.Run("it is configured with the given driver", func(t *testing.T) {
+		if service.Driver != driver {
+			t.Errorf("Expected the service to use the given driver")
+		}
+	})
+}
+
+func TestAutoScalingPolicyService_ForAutoScalingGroup(t *testing.T) {
+	group := &AutoScalingGroup{ID: "1"}
+	driver := NewMockDriver()
+	service := NewAutoScalingPolicyService(driver)
+
+	t.Run("when there are matching policies", func(t *testing.T) {
+		policy1 := &AutoScalingPolicy{ID: "1"}
+		policy2 := &AutoScalingPolicy{ID: "2"}
+
+		stubAutoScalingGroupPolicies(driver, group, policy1, policy2)
+
+		all := service.ForAutoScalingGroup(group, nil)
+
+		t.Run("it contains all matching policies", func(t *testing.T) {
+			if len(all) != 2 {
+				t.Errorf("Expected 2 policies, got %d", len(all))
+			}
+		})
+	})
+
+	t.Run("when there are no matching policies", func(t *testing.T) {
+		driver.Reset()
+
+		t.Run("it is empty", func(t *testing.T) {
+			if all := service.ForAutoScalingGroup(group, nil); len(all) != 0 {
+				t.Errorf("Expected 0 policies, got %d", len(all))
+			}
+		})
+	})
+}
+
+func TestAutoScalingPolicyService_Find(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAutoScalingPolicyService(driver)
+	stubAutoScalingPolicy(driver, &AutoScalingPolicy{ID: "1"})
+
+	t.Run("for a known policy", func(t *testing.T) {
+		result, err := service.Find("1")
+
+		t.Run("it is the requested policy", func(t *testing.T) {
+			if result == nil || result.ID != "1" {
+				t.Errorf("Expected policy 1, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("for an unknown policy", func(t *testing.T) {
+		if _, err := service.Find("2"); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestAutoScalingPolicy_Validate(t *testing.T) {
+	valid := map[string]*AutoScalingPolicy{
+		"simple": {Name: "up", Type: SimplePolicy, ActionType: "add"},
+		"step":   {Name: "up", Type: StepPolicy, ActionType: "add"},
+		"target": {Name: "cpu", Type: TargetPolicy, MetricType: "cpu", TargetValue: 60},
+	}
+
+	invalid := map[string]*AutoScalingPolicy{
+		"unnamed":             {Type: SimplePolicy, ActionType: "add"},
+		"unknown type":        {Name: "up", Type: "sideways"},
+		"simple, no action":   {Name: "up", Type: SimplePolicy},
+		"target, no metric":   {Name: "cpu", Type: TargetPolicy, TargetValue: 60},
+		"target, zero target": {Name: "cpu", Type: TargetPolicy, MetricType: "cpu"},
+	}
+
+	for name, policy := range valid {
+		t.Run("it accepts a "+name+" policy", func(t *testing.T) {
+			if err := policy.Validate(); err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+	}
+
+	for name, policy := range invalid {
+		t.Run("it rejects a policy that is "+name, func(t *testing.T) {
+			if err := policy.Validate(); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestAutoScalingPolicyService_Create(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAutoScalingPolicyService(driver)
+	group := &AutoScalingGroup{ID: "1"}
+
+	t.Run("with an invalid policy", func(t *testing.T) {
+		if _, err := service.Create(group, &AutoScalingPolicy{Name: "up"}); err == nil {
+			t.Errorf("Expected an error")
+		}
+
+		if len(driver.Requests("post")) != 0 {
+			t.Errorf("Expected no post requests")
+		}
+	})
+
+	t.Run("with a valid policy", func(t *testing.T) {
+		driver.AddResponse(
+			"post",
+			"auto_scaling_groups/1/auto_scaling_policies",
+			Response{Pages: [][]byte{[]byte(`{"auto_scaling_policy": {"id": "5", "name": "up"}}`)}},
+		)
+
+		result, err := service.Create(
+			group,
+			&AutoScalingPolicy{ID: "ignored", Name: "up", Type: SimplePolicy, ActionType: "add", ActionValue: 1},
+		)
+
+		t.Run("it sends only the policy settings", func(t *testing.T) {
+			bodies := driver.Bodies("post")
+			expected := `{"auto_scaling_policy":{"name":"up","type":"simple","action_type":"add","action_value":1,"cooldown":0,"estimated_warmup":0,"target_value":0,"disable_scale_in":false}}`
+
+			if len(bodies) != 1 || string(bodies[0]) != expected {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns the new policy", func(t *testing.T) {
+			if result == nil || result.ID != "5" {
+				t.Errorf("Expected policy 5, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestAutoScalingPolicyService_Update(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAutoScalingPolicyService(driver)
+
+	t.Run("without an ID", func(t *testing.T) {
+		if _, err := service.Update(&AutoScalingPolicy{Name: "up", Type: SimplePolicy, ActionType: "add"}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with a valid policy", func(t *testing.T) {
+		driver.AddResponse(
+			"put",
+			"auto_scaling_policies/5",
+			Response{Pages: [][]byte{[]byte(`{"auto_scaling_policy": {"id": "5", "cooldown": 300}}`)}},
+		)
+
+		result, err := service.Update(&AutoScalingPolicy{ID: "5", Name: "up", Type: SimplePolicy, ActionType: "add", Cooldown: 300})
+
+		t.Run("it returns the updated policy", func(t *testing.T) {
+			if result == nil || result.Cooldown != 300 {
+				t.Errorf("Expected the updated policy, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when turning settings off", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse(
+			"put",
+			"auto_scaling_policies/5",
+			Response{Pages: [][]byte{[]byte(`{"auto_scaling_policy": {"id": "5"}}`)}},
+		)
+
+		service.Update(&AutoScalingPolicy{ID: "5", Name: "x", Type: SimplePolicy, ActionType: "add", DisableScaleIn: false})
+
+		t.Run("it sends the zero values", func(t *testing.T) {
+			body := string(driver.Bodies("put")[0])
+
+			for _, part := range []string{`"disable_scale_in":false`, `"action_value":0`} {
+				if !strings.Contains(body, part) {
+					t.Errorf("Expected %s in body %s", part, body)
+				}
+			}
+		})
+	})
+}
+
+func TestAutoScalingPolicyService_Destroy(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewAutoScalingPolicyService(driver)
+
+	t.Run("when the API accepts the deletion", func(t *testing.T) {
+		driver.AddResponse("delete", "auto_scaling_policies/5", Response{})
+
+		if err := service.Destroy(&AutoScalingPolicy{ID: "5"}); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("when the API rejects the deletion", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "auto_scaling_policies/5", Response{Error: fmt.Errorf("Oh no!")})
+
+		if err := service.Destroy(&AutoScalingPolicy{ID: "5"}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func stubAutoScalingGroupPolicies(driver *MockDriver, group *AutoScalingGroup, policies ...*AutoScalingPolicy) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		AutoScalingPolicies []*AutoScalingPolicy `json:"auto_scaling_policies,omitempty"`
+	}{AutoScalingPolicies: policies}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "auto_scaling_groups/"+group.ID+"/auto_scaling_policies", Response{Pages: pages})
+	}
+}
+
+func stubAutoScalingPolicy(driver *MockDriver, policy *AutoScalingPolicy) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		AutoScalingPolicy *AutoScalingPolicy `json:"auto_scaling_policy,omitempty"`
+	}{AutoScalingPolicy: policy}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "auto_scaling_policies/"+policy.ID, Response{Pages: pages})
+	}
+}
@@ -0,0 +1,274 @@
+package eygo
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ChefRun is a data structure that models a single chef run as described by
+// a chef log.
+type ChefRun struct {
+	StartedAt      time.Time
+	FinishedAt     time.Time
+	Complete       bool
+	Failed         bool
+	Recipe         string
+	Recipes        []string
+	ErrorBlock     string
+	ExceptionClass string
+	Lines          []string
+}
+
+// Successful returns true if the run completed without a failure, and false
+// otherwise.
+func (run *ChefRun) Successful() bool {
+	return run.Complete && !run.Failed
+}
+
+// ChefStatus is a typed version of the chef status entries that are reported
+// for a Server.
+type ChefStatus struct {
+	Message   string
+	Timestamp time.Time
+	TimeAgo   string
+}
+
+// Failed returns true if the status describes a failed chef run, and false
+// otherwise.
+func (status *ChefStatus) Failed() bool {
+	message := strings.ToLower(status.Message)
+
+	return strings.Contains(message, "fail") || strings.Contains(message, "error")
+}
+
+// ChefService is a repository one can use to retrieve and inspect the chef
+// logs and chef status for Server records on the API.
+type ChefService struct {
+	Driver Driver
+}
+
+// NewChefService returns a ChefService configured with the provided Driver.
+func NewChefService(driver Driver) *ChefService {
+	return &ChefService{Driver: driver}
+}
+
+// LatestLog returns the raw content of the latest chef log for the given
+// Server. If there are errors in retrieving this information, an error is
+// returned as well.
+func (service *ChefService) LatestLog(server *Server) ([]byte, error) {
+	if server == nil || len(server.LatestChefLogURL) == 0 {
+		return nil, fmt.Errorf("No chef log available for the given server")
+	}
+
+	response := service.Driver.Get(pathFor(server.LatestChefLogURL), nil)
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	return bytes.Join(response.Pages, nil), nil
+}
+
+// LatestRuns returns the chef runs described by the latest chef log for the
+// given Server. If there are errors in retrieving the log, an error is
+// returned as well.
+func (service *ChefService) LatestRuns(server *Server) ([]*ChefRun, error) {
+	log, err := service.LatestLog(server)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseChefLog(log), nil
+}
+
+// Status returns the chef status entries for the given Server, ordered from
+// oldest to newest, with their timestamps parsed.
+func (service *ChefService) Status(server *Server) []*ChefStatus {
+	statuses := make([]*ChefStatus, 0)
+
+	if server == nil {
+		return statuses
+	}
+
+	for _, entry := range server.ChefStatus {
+		timestamp, _ := parseTimestamp(entry.Timestamp)
+
+		statuses = append(
+			statuses,
+			&ChefStatus{
+				Message:   entry.Message,
+				Timestamp: timestamp,
+				TimeAgo:   entry.TimeAgo,
+			},
+		)
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].Timestamp.Before(statuses[j].Timestamp)
+	})
+
+	return statuses
+}
+
+// LastRunFailed returns true if the most recent chef status for the given
+// Server describes a failed run, and false otherwise.
+func (service *ChefService) LastRunFailed(server *Server) bool {
+	statuses := service.Status(server)
+	if len(statuses) == 0 {
+		return false
+	}
+
+	return statuses[len(statuses)-1].Failed()
+}
+
+var (
+	chefLogLine      = regexp.MustCompile(`^\[([^\]]+)\]\s+([A-Z]+):\s?(.*)$`)
+	chefRecipeLine   = regexp.MustCompile(`Recipe:\s+(\S+::\S+)`)
+	chefResourceLine = regexp.MustCompile(`\((\S+::\S+) line \d+\)`)
+	chefBanner       = regexp.MustCompile(`^={10,}$`)
+	chefException    = regexp.MustCompile(`^((?:[A-Z]\w*::)*[A-Z]\w*)(?::\s|$)`)
+)
+
+// ParseChefLog takes the raw content of a chef log and returns the chef runs
+// that it describes, in the order in which they appear.
+func ParseChefLog(log []byte) []*ChefRun {
+	runs := make([]*ChefRun, 0)
+
+	var run *ChefRun
+	capturing := false
+	errorLines := make([]string, 0)
+
+	finish := func() {
+		if run != nil {
+			run.ErrorBlock = strings.TrimSpace(strings.Join(errorLines, "\n"))
+			if len(run.ExceptionClass) == 0 {
+				run.ExceptionClass = exceptionFromBlock(errorLines)
+			}
+			runs = append(runs, run)
+		}
+
+		run = nil
+		capturing = false
+		errorLines = make([]string, 0)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(log))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		var timestamp time.Time
+		level := ""
+		message := line
+
+		if match := chefLogLine.FindStringSubmatch(line); match != nil {
+			timestamp, _ = parseTimestamp(match[1])
+			level = match[2]
+			message = match[3]
+		}
+
+		if strings.Contains(message, "Starting Chef Client") || strings.Contains(message, "Starting Chef Infra Client") {
+			finish()
+		}
+
+		if run == nil {
+			if len(strings.TrimSpace(line)) == 0 {
+				continue
+			}
+
+			run = &ChefRun{Recipes: make([]string, 0), Lines: make([]string, 0)}
+		}
+
+		run.Lines = append(run.Lines, line)
+
+		if run.StartedAt.IsZero() && !timestamp.IsZero() {
+			run.StartedAt = timestamp
+		}
+
+		if recipe := chefRecipe(message); len(recipe) > 0 && !run.Failed && !capturing {
+			run.Recipe = recipe
+			if len(run.Recipes) == 0 || run.Recipes[len(run.Recipes)-1] != recipe {
+				run.Recipes = append(run.Recipes, recipe)
+			}
+		}
+
+		switch {
+		case level == "FATAL":
+			capturing = false
+			run.Failed = true
+			run.Complete = true
+			run.FinishedAt = timestamp
+
+			if match := chefException.FindStringSubmatch(message); match != nil && len(run.ExceptionClass) == 0 {
+				run.ExceptionClass = match[1]
+			}
+
+		case strings.Contains(message, "Running exception handlers"):
+			capturing = false
+			run.Failed = true
+
+		case strings.Contains(message, "Chef Run complete") || strings.Contains(message, "Chef Client finished") || strings.Contains(message, "Chef Infra Client finished"):
+			capturing = false
+			run.Complete = true
+			run.FinishedAt = timestamp
+
+		case capturing:
+			errorLines = append(errorLines, line)
+
+		case !run.Failed && len(errorLines) == 0 && (level == "ERROR" || chefBanner.MatchString(strings.TrimSpace(line))):
+			capturing = true
+			run.Failed = true
+			errorLines = append(errorLines, line)
+		}
+	}
+
+	finish()
+
+	return runs
+}
+
+func chefRecipe(message string) string {
+	if match := chefRecipeLine.FindStringSubmatch(message); match != nil {
+		return match[1]
+	}
+
+	if match := chefResourceLine.FindStringSubmatch(message); match != nil {
+		return match[1]
+	}
+
+	return ""
+}
+
+func exceptionFromBlock(lines []string) string {
+	for _, line := range lines {
+		candidate := strings.TrimSpace(line)
+		if strings.Contains(candidate, "::") {
+			if match := chefException.FindStringSubmatch(candidate); match != nil && match[1] == candidate {
+				return candidate
+			}
+		}
+	}
+
+	return ""
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
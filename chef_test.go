@@ -0,0 +1,291 @@
+package eygo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const successfulChefLog = `[2018-06-15T10:00:00+00:00] INFO: Starting Chef Client, version 12.19.36
+[2018-06-15T10:00:05+00:00] INFO: Processing package[nginx] action install (nginx::default line 3)
+[2018-06-15T10:00:09+00:00] INFO: Processing service[nginx] action start (nginx::service line 7)
+[2018-06-15T10:01:00+00:00] INFO: Chef Run complete in 60.1 seconds
+`
+
+const failedChefLog = `[2018-06-16T10:00:00+00:00] INFO: Starting Chef Client, version 12.19.36
+[2018-06-16T10:00:05+00:00] INFO: Processing package[nginx] action install (nginx::default line 3)
+[2018-06-16T10:00:09+00:00] INFO: Processing execute[migrate] action run (app::deploy line 12)
+[2018-06-16T10:00:10+00:00] ERROR: execute[migrate] (app::deploy line 12) had an error: Mixlib::ShellOut::ShellCommandFailed: Expected process to exit with [0], but received '1'
+---- Begin output of rake db:migrate ----
+STDOUT:
+STDERR: rake aborted!
+---- End output of rake db:migrate ----
+[2018-06-16T10:00:11+00:00] ERROR: Running exception handlers
+[2018-06-16T10:00:11+00:00] FATAL: Stacktrace dumped to /var/chef/cache/chef-stacktrace.out
+[2018-06-16T10:00:12+00:00] FATAL: Mixlib::ShellOut::ShellCommandFailed: execute[migrate] (app::deploy line 12) had an error
+`
+
+func TestNewChefService(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewChefService(driver)
+
+	t.Run("it is configured with the given driver", func(t *testing.T) {
+		if service.Driver != driver {
+			t.Errorf("Expected the service to use the given driver")
+		}
+	})
+}
+
+func TestChefService_LatestLog(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewChefService(driver)
+	server := &Server{
+		ID:               1,
+		LatestChefLogURL: "https://api.engineyard.com/servers/1/chef_logs/latest",
+	}
+
+	t.Run("for a server without a chef log", func(t *testing.T) {
+		_, err := service.LatestLog(&Server{ID: 2})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when the log is available", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse(
+			"get",
+			"servers/1/chef_logs/latest",
+			Response{Pages: [][]byte{[]byte(successfulChefLog)}},
+		)
+
+		result, err := service.LatestLog(server)
+
+		t.Run("it returns the log content", func(t *testing.T) {
+			if string(result) != successfulChefLog {
+				t.Errorf("Expected the chef log, got '%s'", string(result))
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when the log is not available", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse(
+			"get",
+			"servers/1/chef_logs/latest",
+			Response{Error: fmt.Errorf("Oh no!")},
+		)
+
+		_, err := service.LatestLog(server)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestChefService_LatestRuns(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewChefService(driver)
+	server := &Server{ID: 1, LatestChefLogURL: "/servers/1/chef_logs/latest"}
+
+	driver.AddResponse(
+		"get",
+		"servers/1/chef_logs/latest",
+		Response{Pages: [][]byte{[]byte(successfulChefLog + failedChefLog)}},
+	)
+
+	runs, err := service.LatestRuns(server)
+
+	t.Run("it returns no error", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("Expected no error")
+		}
+	})
+
+	t.Run("it returns every run in the log", func(t *testing.T) {
+		if len(runs) != 2 {
+			t.Fatalf("Expected 2 runs, got %d", len(runs))
+		}
+
+		if !runs[0].Successful() {
+			t.Errorf("Expected the first run to be successful")
+		}
+
+		if runs[1].Successful() {
+			t.Errorf("Expected the second run to have failed")
+		}
+	})
+}
+
+func TestParseChefLog(t *testing.T) {
+	t.Run("for a successful run", func(t *testing.T) {
+		runs := ParseChefLog([]byte(successfulChefLog))
+
+		if len(runs) != 1 {
+			t.Fatalf("Expected 1 run, got %d", len(runs))
+		}
+
+		run := runs[0]
+
+		t.Run("it is successful", func(t *testing.T) {
+			if !run.Successful() {
+				t.Errorf("Expected a successful run")
+			}
+		})
+
+		t.Run("it knows when the run started and finished", func(t *testing.T) {
+			if run.StartedAt.Format("15:04:05") != "10:00:00" {
+				t.Errorf("Expected a start of 10:00:00, got %s", run.StartedAt)
+			}
+
+			if run.FinishedAt.Format("15:04:05") != "10:01:00" {
+				t.Errorf("Expected a finish of 10:01:00, got %s", run.FinishedAt)
+			}
+		})
+
+		t.Run("it records the recipes that were run", func(t *testing.T) {
+			if len(run.Recipes) != 2 || run.Recipes[0] != "nginx::default" || run.Recipes[1] != "nginx::service" {
+				t.Errorf("Unexpected recipes: %v", run.Recipes)
+			}
+		})
+
+		t.Run("it has no error block", func(t *testing.T) {
+			if len(run.ErrorBlock) != 0 {
+				t.Errorf("Expected no error block, got '%s'", run.ErrorBlock)
+			}
+		})
+	})
+
+	t.Run("for a failed run", func(t *testing.T) {
+		runs := ParseChefLog([]byte(failedChefLog))
+
+		if len(runs) != 1 {
+			t.Fatalf("Expected 1 run, got %d", len(runs))
+		}
+
+		run := runs[0]
+
+		t.Run("it is failed", func(t *testing.T) {
+			if !run.Failed || run.Successful() {
+				t.Errorf("Expected a failed run")
+			}
+		})
+
+		t.Run("it knows the failing recipe", func(t *testing.T) {
+			if run.Recipe != "app::deploy" {
+				t.Errorf("Expected app::deploy, got '%s'", run.Recipe)
+			}
+		})
+
+		t.Run("it knows the exception class", func(t *testing.T) {
+			if run.ExceptionClass != "Mixlib::ShellOut::ShellCommandFailed" {
+				t.Errorf("Expected Mixlib::ShellOut::ShellCommandFailed, got '%s'", run.ExceptionClass)
+			}
+		})
+
+		t.Run("it captures the error block", func(t *testing.T) {
+			lines := len(strings.Split(run.ErrorBlock, "\n"))
+			if lines != 5 {
+				t.Errorf("Expected 5 lines in the error block, got %d:\n%s", lines, run.ErrorBlock)
+			}
+		})
+
+		t.Run("it knows when the run finished", func(t *testing.T) {
+			if run.FinishedAt.Format("15:04:05") != "10:00:12" {
+				t.Errorf("Expected a finish of 10:00:12, got %s", run.FinishedAt)
+			}
+		})
+	})
+
+	t.Run("for a run that is still in progress", func(t *testing.T) {
+		runs := ParseChefLog([]byte("[2018-06-15T10:00:00+00:00] INFO: Starting Chef Client, version 12.19.36\n"))
+
+		t.Run("it is neither complete nor failed", func(t *testing.T) {
+			if len(runs) != 1 || runs[0].Complete || runs[0].Failed {
+				t.Errorf("Expected a single incomplete run")
+			}
+		})
+	})
+
+	t.Run("for an empty log", func(t *testing.T) {
+		t.Run("it has no runs", func(t *testing.T) {
+			if runs := ParseChefLog(nil); len(runs) != 0 {
+				t.Errorf("Expected no runs, got %d", len(runs))
+			}
+		})
+	})
+}
+
+func TestChefService_LastRunFailed(t *testing.T) {
+	service := NewChefService(NewMockDriver())
+
+	t.Run("when the latest status is a failure", func(t *testing.T) {
+		server := chefStatusServer(
+			`{"message": "Chef run failed", "timestamp": "2018-06-16T10:00:00+00:00"}`,
+			`{"message": "Chef run complete", "timestamp": "2018-06-15T10:00:00+00:00"}`,
+		)
+
+		t.Run("it is true", func(t *testing.T) {
+			if !service.LastRunFailed(server) {
+				t.Errorf("Expected the last run to have failed")
+			}
+		})
+
+		t.Run("the statuses are ordered and parsed", func(t *testing.T) {
+			statuses := service.Status(server)
+
+			if len(statuses) != 2 {
+				t.Fatalf("Expected 2 statuses, got %d", len(statuses))
+			}
+
+			if statuses[0].Timestamp.Day() != 15 || statuses[1].Timestamp.Day() != 16 {
+				t.Errorf("Expected statuses to be ordered oldest to newest")
+			}
+		})
+	})
+
+	t.Run("when the latest status is a success", func(t *testing.T) {
+		server := chefStatusServer(
+			`{"message": "Chef run failed", "timestamp": "2018-06-15T10:00:00+00:00"}`,
+			`{"message": "Chef run complete", "timestamp": "2018-06-16T10:00:00+00:00"}`,
+		)
+
+		t.Run("it is false", func(t *testing.T) {
+			if service.LastRunFailed(server) {
+				t.Errorf("Expected the last run to have succeeded")
+			}
+		})
+	})
+
+	t.Run("when there is no status", func(t *testing.T) {
+		t.Run("it is false", func(t *testing.T) {
+			if service.LastRunFailed(&Server{}) {
+				t.Errorf("Expected no failure")
+			}
+		})
+	})
+}
+
+func chefStatusServer(statuses ...string) *Server {
+	server := &Server{}
+
+	json.Unmarshal(
+		[]byte(`{"chef_status": [`+strings.Join(statuses, ",")+`]}`),
+		server,
+	)
+
+	return server
+}
@@ -0,0 +1,54 @@
+package eygo
+
+import (
+	"fmt"
+	"net"
+)
+
+// ParseCIDR takes a string in CIDR notation and returns the network that it
+// describes. Unlike net.ParseCIDR, it rejects blocks that have host bits set
+// (such as 10.0.0.1/16), as the API only deals in network addresses.
+func ParseCIDR(cidr string) (*net.IPNet, error) {
+	ip, block, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid CIDR %q", cidr)
+	}
+
+	if !ip.Equal(block.IP) {
+		return nil, fmt.Errorf("CIDR %s has host bits set, did you mean %s?", cidr, block)
+	}
+
+	return block, nil
+}
+
+// cidrContains returns true if the inner block lies entirely within the outer
+// block.
+func cidrContains(outer *net.IPNet, inner *net.IPNet) bool {
+	outerSize, outerBits := outer.Mask.Size()
+	innerSize, innerBits := inner.Mask.Size()
+
+	return outerBits == innerBits &&
+		outerSize <= innerSize &&
+		outer.Contains(inner.IP)
+}
+
+// cidrOverlaps returns true if the two blocks share any addresses.
+func cidrOverlaps(a *net.IPNet, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
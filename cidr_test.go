@@ -0,0 +1,72 @@
+package eygo
+
+import (
+	"testing"
+)
+
+func TestParseCIDR(t *testing.T) {
+	t.Run("it accepts network addresses", func(t *testing.T) {
+		for _, cidr := range []string{"10.0.0.0/16", "172.16.4.0/24", "fd00::/64"} {
+			block, err := ParseCIDR(cidr)
+			if err != nil {
+				t.Errorf("Expected %s to parse, got %s", cidr, err)
+				continue
+			}
+
+			if block.String() != cidr {
+				t.Errorf("Expected %s, got %s", cidr, block)
+			}
+		}
+	})
+
+	t.Run("it rejects malformed blocks", func(t *testing.T) {
+		for _, cidr := range []string{"", "10.0.0.0", "10.0.0.0/33", "ten/8"} {
+			if _, err := ParseCIDR(cidr); err == nil {
+				t.Errorf("Expected %q to be rejected", cidr)
+			}
+		}
+	})
+
+	t.Run("it rejects blocks with host bits set", func(t *testing.T) {
+		if _, err := ParseCIDR("10.0.0.1/16"); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestCIDRContainment(t *testing.T) {
+	network, _ := ParseCIDR("10.0.0.0/16")
+	inside, _ := ParseCIDR("10.0.4.0/24")
+	outside, _ := ParseCIDR("10.1.0.0/24")
+	larger, _ := ParseCIDR("10.0.0.0/8")
+
+	t.Run("it contains blocks within the network", func(t *testing.T) {
+		if !cidrContains(network, inside) {
+			t.Errorf("Expected %s to contain %s", network, inside)
+		}
+
+		if !cidrContains(network, network) {
+			t.Errorf("Expected %s to contain itself", network)
+		}
+	})
+
+	t.Run("it doesn't contain blocks outside of the network", func(t *testing.T) {
+		if cidrContains(network, outside) {
+			t.Errorf("Expected %s not to contain %s", network, outside)
+		}
+
+		if cidrContains(network, larger) {
+			t.Errorf("Expected %s not to contain %s", network, larger)
+		}
+	})
+
+	t.Run("it detects overlapping blocks", func(t *testing.T) {
+		if !cidrOverlaps(inside, network) || !cidrOverlaps(larger, inside) {
+			t.Errorf("Expected the blocks to overlap")
+		}
+
+		if cidrOverlaps(inside, outside) {
+			t.Errorf("Expected %s and %s not to overlap", inside, outside)
+		}
+	})
+}
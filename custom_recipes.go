@@ -0,0 +1,353 @@
+package eygo
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CustomRecipes is a data structure that models the custom chef recipes
+// archive that is currently uploaded for an Environment on the Engine Yard
+// API.
+type CustomRecipes struct {
+	Filename   string `json:"filename,omitempty"`
+	Checksum   string `json:"checksum,omitempty"`
+	Size       int64  `json:"size,omitempty"`
+	UploadedAt string `json:"uploaded_at,omitempty"`
+}
+
+// CookbookArchive is a data structure that describes a packaged cookbooks
+// directory that is ready to be uploaded.
+type CookbookArchive struct {
+	// Data is the gzipped tarball itself.
+	Data []byte
+
+	// Checksum is the hex-encoded SHA-256 digest of Data.
+	Checksum string
+
+	// Files lists the paths within the tarball, in the order that they were
+	// written.
+	Files []string
+}
+
+// PackageCookbooks packages the cookbooks directory at the given path into a
+// gzipped tarball rooted at "cookbooks/". The tarball doesn't record
+// modification times or ownership, so packaging the same content twice
+// produces the same checksum. Version control directories are skipped.
+func PackageCookbooks(dir string) (*CookbookArchive, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	buffer := &bytes.Buffer{}
+	zipper := gzip.NewWriter(buffer)
+	tarball := tar.NewWriter(zipper)
+	files := make([]string, 0)
+
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		name := filepath.ToSlash(filepath.Join("cookbooks", relative))
+
+		header := &tar.Header{Name: name, ModTime: time.Unix(0, 0)}
+
+		switch {
+		case info.IsDir():
+			header.Typeflag = tar.TypeDir
+			header.Name = name + "/"
+			header.Mode = 0755
+		case info.Mode().IsRegular():
+			header.Typeflag = tar.TypeReg
+			header.Size = info.Size()
+			header.Mode = 0644
+			if info.Mode()&0111 != 0 {
+				header.Mode = 0755
+			}
+		default:
+			return fmt.Errorf("Unsupported file %s", path)
+		}
+
+		if err := tarball.WriteHeader(header); err != nil {
+			return err
+		}
+
+		files = append(files, header.Name)
+
+		if header.Typeflag == tar.TypeReg {
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			if _, err := io.Copy(tarball, file); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tarball.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := zipper.Close(); err != nil {
+		return nil, err
+	}
+
+	return &CookbookArchive{
+		Data:     buffer.Bytes(),
+		Checksum: checksumOf(buffer.Bytes()),
+		Files:    files,
+	}, nil
+}
+
+// ArchiveFiles returns the paths contained in the given gzipped tarball.
+func ArchiveFiles(data []byte) ([]string, error) {
+	zipped, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zipped.Close()
+
+	tarball := tar.NewReader(zipped)
+	files := make([]string, 0)
+
+	for {
+		header, err := tarball.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, header.Name)
+	}
+
+	return files, nil
+}
+
+// CustomRecipeService is a repository one can use to manage the custom chef
+// recipes for an Environment on the API.
+type CustomRecipeService struct {
+	Driver Driver
+}
+
+// NewCustomRecipeService returns a CustomRecipeService configured to use the
+// provided Driver.
+func NewCustomRecipeService(driver Driver) *CustomRecipeService {
+	return &CustomRecipeService{Driver: driver}
+}
+
+// Current returns the CustomRecipes that are currently uploaded for the given
+// Environment. If there are issues along the way, an error is returned.
+func (service *CustomRecipeService) Current(environment *Environment) (*CustomRecipes, error) {
+	if environment == nil || environment.ID == 0 {
+		return nil, fmt.Errorf("No valid environment given")
+	}
+
+	return service.unwrap(service.Driver.Get(service.recipesPath(environment), nil))
+}
+
+// Download returns the custom recipes tarball that is currently uploaded for
+// the given Environment. If the API reports a checksum for the current
+// recipes, the downloaded tarball is verified against it. If there are issues
+// along the way, an error is returned.
+func (service *CustomRecipeService) Download(environment *Environment) ([]byte, error) {
+	current, err := service.Current(environment)
+	if err != nil {
+		return nil, err
+	}
+
+	response := service.Driver.Get(service.recipesPath(environment)+"/download", nil)
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	data := bytes.Join(response.Pages, nil)
+
+	if current != nil && len(current.Checksum) > 0 && current.Checksum != checksumOf(data) {
+		return nil, fmt.Errorf("Downloaded recipes don't match checksum %s", current.Checksum)
+	}
+
+	return data, nil
+}
+
+// List returns the paths contained in the custom recipes tarball that is
+// currently uploaded for the given Environment. If there are issues along the
+// way, an error is returned.
+func (service *CustomRecipeService) List(environment *Environment) ([]string, error) {
+	data, err := service.Download(environment)
+	if err != nil {
+		return nil, err
+	}
+
+	return ArchiveFiles(data)
+}
+
+// Upload uploads the given CookbookArchive as the custom recipes for the given
+// Environment. If the checksum of the current recipes matches that of the
+// archive, nothing is uploaded. The resulting CustomRecipes are returned
+// along with whether or not an upload took place. If there are issues along
+// the way, an error is returned.
+func (service *CustomRecipeService) Upload(environment *Environment, archive *CookbookArchive) (*CustomRecipes, bool, error) {
+	if environment == nil || environment.ID == 0 {
+		return nil, false, fmt.Errorf("No valid environment given")
+	}
+
+	if archive == nil || len(archive.Data) == 0 {
+		return nil, false, fmt.Errorf("No valid archive given")
+	}
+
+	// An environment that has never had recipes uploaded has no current
+	// recipes to fetch, so a failed fetch only means that nothing is skipped.
+	current, err := service.Current(environment)
+	if err == nil && current != nil && current.Checksum == archive.Checksum {
+		return current, false, nil
+	}
+
+	wrapper := struct {
+		CustomRecipes struct {
+			Filename string `json:"filename"`
+			Checksum string `json:"checksum"`
+			File     string `json:"file"`
+		} `json:"custom_recipes"`
+	}{}
+
+	wrapper.CustomRecipes.Filename = "cookbooks.tar.gz"
+	wrapper.CustomRecipes.Checksum = archive.Checksum
+	wrapper.CustomRecipes.File = base64.StdEncoding.EncodeToString(archive.Data)
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, false, err
+	}
+
+	uploaded, err := service.unwrap(
+		service.Driver.Put(service.recipesPath(environment), nil, body),
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return uploaded, true, nil
+}
+
+// UploadDirectory packages the cookbooks directory at the given path and
+// uploads it as Upload does.
+func (service *CustomRecipeService) UploadDirectory(environment *Environment, dir string) (*CustomRecipes, bool, error) {
+	archive, err := PackageCookbooks(dir)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return service.Upload(environment, archive)
+}
+
+// Apply requests that the current custom recipes be run on the servers in the
+// given Environment. The Request that tracks the run is returned. If there
+// are issues along the way, an error is returned.
+func (service *CustomRecipeService) Apply(environment *Environment) (*Request, error) {
+	if environment == nil || environment.ID == 0 {
+		return nil, fmt.Errorf("No valid environment given")
+	}
+
+	response := service.Driver.Post(
+		fmt.Sprintf("environments/%d/apply", environment.ID),
+		nil,
+		[]byte(`{"type":"custom"}`),
+	)
+
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		Request *Request `json:"request,omitempty"`
+	}{}
+
+	if err := json.Unmarshal(response.Pages[0], &wrapper); err != nil {
+		return nil, err
+	}
+
+	return wrapper.Request, nil
+}
+
+func (service *CustomRecipeService) recipesPath(environment *Environment) string {
+	if len(environment.CustomRecipesURL) > 0 {
+		return pathFor(environment.CustomRecipesURL)
+	}
+
+	return fmt.Sprintf("environments/%d/custom_recipes", environment.ID)
+}
+
+func (service *CustomRecipeService) unwrap(response Response) (*CustomRecipes, error) {
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		CustomRecipes *CustomRecipes `json:"custom_recipes,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.CustomRecipes, nil
+}
+
+func checksumOf(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
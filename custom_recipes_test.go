@@ -0,0 +1,284 @@
+package eygo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPackageCookbooks(t *testing.T) {
+	dir := cookbooksFixture(t)
+	defer os.RemoveAll(dir)
+
+	archive, err := PackageCookbooks(dir)
+
+	t.Run("it returns no error", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("it records the files under cookbooks", func(t *testing.T) {
+		expected := []string{
+			"cookbooks/",
+			"cookbooks/main/",
+			"cookbooks/main/recipes/",
+			"cookbooks/main/recipes/default.rb",
+		}
+
+		if strings.Join(archive.Files, ",") != strings.Join(expected, ",") {
+			t.Errorf("Expected %v, got %v", expected, archive.Files)
+		}
+	})
+
+	t.Run("it skips version control directories", func(t *testing.T) {
+		for _, file := range archive.Files {
+			if strings.Contains(file, ".git") {
+				t.Errorf("Expected %s to be skipped", file)
+			}
+		}
+	})
+
+	t.Run("it produces a readable tarball", func(t *testing.T) {
+		files, err := ArchiveFiles(archive.Data)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if len(files) != len(archive.Files) {
+			t.Errorf("Expected %d files, got %d", len(archive.Files), len(files))
+		}
+	})
+
+	t.Run("it produces the same checksum for the same content", func(t *testing.T) {
+		other, _ := PackageCookbooks(dir)
+
+		if other.Checksum != archive.Checksum {
+			t.Errorf("Expected matching checksums")
+		}
+	})
+
+	t.Run("it produces a different checksum for different content", func(t *testing.T) {
+		ioutil.WriteFile(filepath.Join(dir, "main", "recipes", "default.rb"), []byte("# changed\n"), 0644)
+
+		other, _ := PackageCookbooks(dir)
+
+		if other.Checksum == archive.Checksum {
+			t.Errorf("Expected different checksums")
+		}
+	})
+
+	t.Run("with a missing directory", func(t *testing.T) {
+		if _, err := PackageCookbooks(filepath.Join(dir, "missing")); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestNewCustomRecipeService(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewCustomRecipeService(driver)
+
+	t.Run("it is configured with the given driver", func(t *testing.T) {
+		if service.Driver != driver {
+			t.Errorf("Expected the service to use the given driver")
+		}
+	})
+}
+
+func TestCustomRecipeService_Upload(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewCustomRecipeService(driver)
+	environment := &Environment{ID: 1}
+	archive := &CookbookArchive{Data: []byte("tarball"), Checksum: checksumOf([]byte("tarball"))}
+
+	t.Run("with an invalid archive", func(t *testing.T) {
+		if _, _, err := service.Upload(environment, &CookbookArchive{}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with an invalid environment", func(t *testing.T) {
+		for _, invalid := range []*Environment{nil, {}} {
+			if _, _, err := service.Upload(invalid, archive); err == nil {
+				t.Errorf("Expected an error")
+			}
+		}
+
+		if len(driver.Requests("put")) != 0 {
+			t.Errorf("Expected no put requests")
+		}
+	})
+
+	t.Run("when the checksum is unchanged", func(t *testing.T) {
+		driver.Reset()
+		stubCustomRecipes(driver, "get", "environments/1/custom_recipes", &CustomRecipes{Checksum: archive.Checksum})
+
+		_, uploaded, err := service.Upload(environment, archive)
+
+		t.Run("it skips the upload", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+
+			if uploaded || len(driver.Requests("put")) != 0 {
+				t.Errorf("Expected no upload")
+			}
+		})
+	})
+
+	t.Run("when the checksum differs", func(t *testing.T) {
+		driver.Reset()
+		stubCustomRecipes(driver, "get", "environments/1/custom_recipes", &CustomRecipes{Checksum: "other"})
+		stubCustomRecipes(driver, "put", "environments/1/custom_recipes", &CustomRecipes{Checksum: archive.Checksum})
+
+		recipes, uploaded, err := service.Upload(environment, archive)
+
+		t.Run("it uploads the archive", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if !uploaded || recipes.Checksum != archive.Checksum {
+				t.Errorf("Expected an upload")
+			}
+
+			wrapper := struct {
+				CustomRecipes struct {
+					Checksum string `json:"checksum"`
+					File     string `json:"file"`
+				} `json:"custom_recipes"`
+			}{}
+
+			json.Unmarshal(driver.Bodies("put")[0], &wrapper)
+
+			if wrapper.CustomRecipes.Checksum != archive.Checksum {
+				t.Errorf("Expected the checksum to be sent")
+			}
+
+			if decoded, _ := base64.StdEncoding.DecodeString(wrapper.CustomRecipes.File); string(decoded) != "tarball" {
+				t.Errorf("Expected the archive to be sent")
+			}
+		})
+	})
+
+	t.Run("when there are no current recipes", func(t *testing.T) {
+		driver.Reset()
+		environment := &Environment{ID: 2, CustomRecipesURL: "https://api.engineyard.com/environments/2/custom_recipes"}
+		stubCustomRecipes(driver, "put", "environments/2/custom_recipes", &CustomRecipes{Checksum: archive.Checksum})
+
+		if _, uploaded, err := service.Upload(environment, archive); err != nil || !uploaded {
+			t.Errorf("Expected an upload, got %v", err)
+		}
+	})
+
+	t.Run("when the upload fails", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("put", "environments/1/custom_recipes", Response{Error: fmt.Errorf("Oh no!")})
+
+		if _, _, err := service.Upload(environment, archive); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestCustomRecipeService_Download(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewCustomRecipeService(driver)
+	environment := &Environment{ID: 1}
+
+	dir := cookbooksFixture(t)
+	defer os.RemoveAll(dir)
+
+	archive, _ := PackageCookbooks(dir)
+
+	t.Run("when the checksum matches", func(t *testing.T) {
+		stubCustomRecipes(driver, "get", "environments/1/custom_recipes", &CustomRecipes{Checksum: archive.Checksum})
+		driver.AddResponse("get", "environments/1/custom_recipes/download", Response{Pages: [][]byte{archive.Data}})
+
+		files, err := service.List(environment)
+
+		t.Run("it lists the archive contents", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if len(files) != len(archive.Files) {
+				t.Errorf("Expected %d files, got %d", len(archive.Files), len(files))
+			}
+		})
+	})
+
+	t.Run("when the checksum doesn't match", func(t *testing.T) {
+		driver.Reset()
+		stubCustomRecipes(driver, "get", "environments/1/custom_recipes", &CustomRecipes{Checksum: "other"})
+		driver.AddResponse("get", "environments/1/custom_recipes/download", Response{Pages: [][]byte{archive.Data}})
+
+		if _, err := service.Download(environment); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with an invalid environment", func(t *testing.T) {
+		if _, err := service.Download(&Environment{}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestCustomRecipeService_Apply(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewCustomRecipeService(driver)
+	environment := &Environment{ID: 1}
+
+	t.Run("when successful", func(t *testing.T) {
+		driver.AddResponse(
+			"post",
+			"environments/1/apply",
+			Response{Pages: [][]byte{[]byte(`{"request":{"id":"abc"}}`)}},
+		)
+
+		request, err := service.Apply(environment)
+
+		if err != nil || request.ID != "abc" {
+			t.Errorf("Expected the tracking request, got %v (%v)", request, err)
+		}
+	})
+
+	t.Run("when unsuccessful", func(t *testing.T) {
+		driver.AddResponse("post", "environments/1/apply", Response{Error: fmt.Errorf("Oh no!")})
+
+		if _, err := service.Apply(environment); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func cookbooksFixture(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "cookbooks")
+	if err != nil {
+		t.Fatalf("Couldn't create fixture: %s", err)
+	}
+
+	os.MkdirAll(filepath.Join(dir, "main", "recipes"), 0755)
+	os.MkdirAll(filepath.Join(dir, ".git"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "main", "recipes", "default.rb"), []byte("# recipe\n"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref\n"), 0644)
+
+	return dir
+}
+
+func stubCustomRecipes(driver *MockDriver, method string, path string, recipes *CustomRecipes) {
+	wrapper := struct {
+		CustomRecipes *CustomRecipes `json:"custom_recipes"`
+	}{CustomRecipes: recipes}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		driver.AddResponse(method, path, Response{Pages: [][]byte{encoded}})
+	}
+}
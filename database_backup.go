@@ -0,0 +1,249 @@
+package eygo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"strconv"
+)
+
+// backupChunkSize is the number of bytes requested per chunk when
+// downloading a DatabaseBackup.
+const backupChunkSize = 4 << 20
+
+// maxBackupChunks is the number of chunks after which a download of a
+// DatabaseBackup with an unknown size is abandoned, so that a server that
+// ignores the requested range can't keep it going forever.
+var maxBackupChunks = 1 << 14
+
+// DatabaseBackup is a data structure that models a database backup on the
+// Engine Yard API.
+type DatabaseBackup struct {
+	ID             int    `json:"id,omitempty"`
+	State          string `json:"state,omitempty"`
+	Filename       string `json:"filename,omitempty"`
+	Size           int64  `json:"size,omitempty"`
+	Checksum       string `json:"checksum,omitempty"`
+	DatabaseName   string `json:"database_name,omitempty"`
+	EnvironmentURL string `json:"environment,omitempty"`
+	ServerURL      string `json:"server,omitempty"`
+	CreatedAt      string `json:"created_at,omitempty"`
+	FinishedAt     string `json:"finished_at,omitempty"`
+}
+
+// Complete returns true if the backup has finished and can be downloaded, and
+// false otherwise.
+func (backup *DatabaseBackup) Complete() bool {
+	return backup.State == "completed"
+}
+
+// Failed returns true if the upstream API reports that the backup could not
+// be taken, and false otherwise.
+func (backup *DatabaseBackup) Failed() bool {
+	return backup.State == "error" || backup.State == "failed"
+}
+
+// DatabaseBackupService is a repository that one can use to list, request,
+// and download DatabaseBackup records on the API.
+type DatabaseBackupService struct {
+	Driver Driver
+}
+
+// NewDatabaseBackupService returns a DatabaseBackupService configured with the
+// provided Driver.
+func NewDatabaseBackupService(driver Driver) *DatabaseBackupService {
+	return &DatabaseBackupService{Driver: driver}
+}
+
+// ForEnvironment returns an array of DatabaseBackup records that are both
+// associated with the given Environment and matching the given Params.
+func (service *DatabaseBackupService) ForEnvironment(environment *Environment, params Params) []*DatabaseBackup {
+	return service.collection(
+		fmt.Sprintf("environments/%d/database_backups", environment.ID),
+		params,
+	)
+}
+
+// Find returns the DatabaseBackup record identified by the given id. If there
+// are errors in retrieving this information, an error is returned as well.
+func (service *DatabaseBackupService) Find(id string) (*DatabaseBackup, error) {
+	response := service.Driver.Get("database_backups/"+id, nil)
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		DatabaseBackup *DatabaseBackup `json:"database_backup,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.DatabaseBackup, nil
+}
+
+// Create requests an on-demand backup of the databases in the given
+// Environment. Backups are taken asynchronously, so the Request that tracks
+// the backup is returned. If there are issues along the way, an error is
+// returned.
+func (service *DatabaseBackupService) Create(environment *Environment) (*Request, error) {
+	if environment == nil || environment.ID == 0 {
+		return nil, fmt.Errorf("No valid environment given")
+	}
+
+	response := service.Driver.Post(
+		fmt.Sprintf("environments/%d/database_backups", environment.ID),
+		Params{},
+		nil,
+	)
+
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		Request *Request `json:"request,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Request, nil
+}
+
+// Download streams the given DatabaseBackup to the given writer in chunks,
+// verifying the result against the checksum that the API reports for the
+// backup. The number of bytes written is returned. If there are issues along
+// the way, an error is returned.
+func (service *DatabaseBackupService) Download(backup *DatabaseBackup, writer io.Writer) (int64, error) {
+	return service.stream(backup, writer, sha256.New(), 0)
+}
+
+// Resume continues an interrupted download of the given DatabaseBackup into
+// the given file. The bytes already in the file are kept and counted towards
+// the checksum, and only the remainder of the backup is downloaded. The total
+// size of the file is returned. If there are issues along the way, an error
+// is returned.
+func (service *DatabaseBackupService) Resume(backup *DatabaseBackup, file io.ReadWriteSeeker) (int64, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	digest := sha256.New()
+
+	offset, err := io.Copy(digest, file)
+	if err != nil {
+		return 0, err
+	}
+
+	if backup != nil && backup.Size > 0 && offset > backup.Size {
+		return offset, fmt.Errorf("File is larger than backup %d", backup.ID)
+	}
+
+	return service.stream(backup, file, digest, offset)
+}
+
+func (service *DatabaseBackupService) stream(backup *DatabaseBackup, writer io.Writer, digest hash.Hash, offset int64) (int64, error) {
+	if backup == nil || backup.ID == 0 {
+		return offset, fmt.Errorf("No valid backup given")
+	}
+
+	if !backup.Complete() {
+		return offset, fmt.Errorf("Backup %d isn't complete", backup.ID)
+	}
+
+	path := fmt.Sprintf("database_backups/%d/download", backup.ID)
+	output := io.MultiWriter(writer, digest)
+
+	for chunks := 0; backup.Size == 0 || offset < backup.Size; chunks++ {
+		if backup.Size == 0 && chunks == maxBackupChunks {
+			return offset, fmt.Errorf("Download of backup %d exceeded %d chunks", backup.ID, maxBackupChunks)
+		}
+
+		params := Params{}
+		params.Set("offset", strconv.FormatInt(offset, 10))
+		params.Set("length", strconv.Itoa(backupChunkSize))
+
+		response := service.Driver.Get(path, params)
+		if !response.Okay() {
+			return offset, response.Error
+		}
+
+		chunk := bytes.Join(response.Pages, nil)
+		if len(chunk) == 0 {
+			break
+		}
+
+		if len(chunk) > backupChunkSize {
+			return offset, fmt.Errorf(
+				"Download of backup %d returned %d bytes for a %d byte chunk",
+				backup.ID,
+				len(chunk),
+				backupChunkSize,
+			)
+		}
+
+		written, err := output.Write(chunk)
+		offset += int64(written)
+		if err != nil {
+			return offset, err
+		}
+
+		if len(chunk) < backupChunkSize {
+			break
+		}
+	}
+
+	if backup.Size > 0 && offset != backup.Size {
+		return offset, fmt.Errorf("Download stopped at %d of %d bytes", offset, backup.Size)
+	}
+
+	if len(backup.Checksum) > 0 && hex.EncodeToString(digest.Sum(nil)) != backup.Checksum {
+		return offset, fmt.Errorf("Downloaded backup doesn't match checksum %s", backup.Checksum)
+	}
+
+	return offset, nil
+}
+
+func (service *DatabaseBackupService) collection(path string, params Params) []*DatabaseBackup {
+	backups := make([]*DatabaseBackup, 0)
+	response := service.Driver.Get(path, params)
+
+	if response.Okay() {
+		for _, page := range response.Pages {
+			wrapper := struct {
+				DatabaseBackups []*DatabaseBackup `json:"database_backups,omitempty"`
+			}{}
+
+			if err := json.Unmarshal(page, &wrapper); err == nil {
+				backups = append(backups, wrapper.DatabaseBackups...)
+			}
+		}
+	}
+
+	return backups
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
@@ -0,0 +1,287 @@
+package eygo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+)
+
+func TestDatabaseBackup_Complete(t *testing.T) {
+	t.Run("it is true for completed backups", func(t *testing.T) {
+		if !(&DatabaseBackup{State: "completed"}).Complete() {
+			t.Errorf("Expected the backup to be complete")
+		}
+	})
+
+	t.Run("it is false otherwise", func(t *testing.T) {
+		if (&DatabaseBackup{State: "in_progress"}).Complete() {
+			t.Errorf("Expected the backup to be incomplete")
+		}
+	})
+}
+
+func TestDatabaseBackupService_ForEnvironment(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewDatabaseBackupService(driver)
+	environment := &Environment{ID: 1}
+
+	t.Run("when there are matching backups", func(t *testing.T) {
+		stubDatabaseBackups(driver, environment, &DatabaseBackup{ID: 1}, &DatabaseBackup{ID: 2})
+
+		if all := service.ForEnvironment(environment, nil); len(all) != 2 {
+			t.Errorf("Expected 2 backups, got %d", len(all))
+		}
+	})
+
+	t.Run("when there are no matching backups", func(t *testing.T) {
+		driver.Reset()
+
+		if all := service.ForEnvironment(environment, nil); len(all) != 0 {
+			t.Errorf("Expected 0 backups, got %d", len(all))
+		}
+	})
+}
+
+func TestDatabaseBackupService_Find(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewDatabaseBackupService(driver)
+
+	t.Run("when the backup exists", func(t *testing.T) {
+		driver.AddResponse("get", "database_backups/3", Response{
+			Pages: [][]byte{[]byte(`{"database_backup":{"id":3,"state":"completed"}}`)},
+		})
+
+		backup, err := service.Find("3")
+
+		if err != nil || backup.ID != 3 {
+			t.Errorf("Expected backup 3, got %v (%v)", backup, err)
+		}
+	})
+
+	t.Run("when the backup doesn't exist", func(t *testing.T) {
+		if _, err := service.Find("4"); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestDatabaseBackupService_Create(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewDatabaseBackupService(driver)
+
+	t.Run("with an invalid environment", func(t *testing.T) {
+		if _, err := service.Create(&Environment{}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when successful", func(t *testing.T) {
+		driver.AddResponse("post", "environments/1/database_backups", Response{
+			Pages: [][]byte{[]byte(`{"request":{"id":"abc"}}`)},
+		})
+
+		request, err := service.Create(&Environment{ID: 1})
+
+		if err != nil || request.ID != "abc" {
+			t.Errorf("Expected the tracking request, got %v (%v)", request, err)
+		}
+	})
+
+	t.Run("when unsuccessful", func(t *testing.T) {
+		driver.AddResponse("post", "environments/1/database_backups", Response{Error: fmt.Errorf("Oh no!")})
+
+		if _, err := service.Create(&Environment{ID: 1}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestDatabaseBackupService_Download(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewDatabaseBackupService(driver)
+	data := bytes.Repeat([]byte("x"), backupChunkSize+10)
+	backup := &DatabaseBackup{ID: 1, State: "completed", Size: int64(len(data)), Checksum: checksumOf(data)}
+
+	t.Run("with an incomplete backup", func(t *testing.T) {
+		if _, err := service.Download(&DatabaseBackup{ID: 1}, ioutil.Discard); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when every chunk arrives", func(t *testing.T) {
+		stubBackupChunk(driver, backup, 0, data)
+
+		output := &bytes.Buffer{}
+		written, err := service.Download(backup, output)
+
+		t.Run("it writes the whole backup", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if written != backup.Size || !bytes.Equal(output.Bytes(), data) {
+				t.Errorf("Expected %d bytes, got %d", backup.Size, written)
+			}
+		})
+
+		t.Run("it requests the backup in chunks", func(t *testing.T) {
+			if len(driver.Requests("get")) != 2 {
+				t.Errorf("Expected 2 requests, got %d", len(driver.Requests("get")))
+			}
+		})
+	})
+
+	t.Run("when the download is interrupted", func(t *testing.T) {
+		driver.Reset()
+		stubBackupChunk(driver, backup, 0, data[:backupChunkSize])
+		driver.AddResponse("get", backupChunkPath(backup, backupChunkSize), Response{Error: fmt.Errorf("Oh no!")})
+
+		written, err := service.Download(backup, ioutil.Discard)
+
+		t.Run("it reports how much was written", func(t *testing.T) {
+			if err == nil || written != backupChunkSize {
+				t.Errorf("Expected an error after %d bytes, got %d (%v)", backupChunkSize, written, err)
+			}
+		})
+	})
+
+	t.Run("when the checksum doesn't match", func(t *testing.T) {
+		driver.Reset()
+		corrupt := append([]byte{}, data...)
+		corrupt[0] = 'y'
+		stubBackupChunk(driver, backup, 0, corrupt)
+
+		if _, err := service.Download(backup, ioutil.Discard); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when the size is unknown and the backup ends on a chunk boundary", func(t *testing.T) {
+		driver.Reset()
+		unsized := &DatabaseBackup{ID: 1, State: "completed"}
+		stubBackupChunk(driver, unsized, 0, data[:backupChunkSize])
+		driver.AddResponse("get", backupChunkPath(unsized, backupChunkSize), Response{Pages: [][]byte{}})
+
+		written, err := service.Download(unsized, ioutil.Discard)
+
+		t.Run("it stops at the empty chunk", func(t *testing.T) {
+			if err != nil || written != backupChunkSize {
+				t.Errorf("Expected %d bytes, got %d (%v)", backupChunkSize, written, err)
+			}
+		})
+	})
+
+	t.Run("when the server ignores the requested range", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("get", backupChunkPath(backup, 0), Response{Pages: [][]byte{data}})
+
+		written, err := service.Download(backup, ioutil.Discard)
+
+		t.Run("it returns an error without writing", func(t *testing.T) {
+			if err == nil || written != 0 {
+				t.Errorf("Expected an error before writing, got %d bytes (%v)", written, err)
+			}
+		})
+	})
+
+	t.Run("when the size is unknown and the chunks never end", func(t *testing.T) {
+		driver.Reset()
+		defer func(max int) { maxBackupChunks = max }(maxBackupChunks)
+		maxBackupChunks = 2
+
+		unsized := &DatabaseBackup{ID: 1, State: "completed"}
+		stubBackupChunk(driver, unsized, 0, data[:backupChunkSize])
+		stubBackupChunk(driver, unsized, backupChunkSize, data[:backupChunkSize])
+		stubBackupChunk(driver, unsized, 2*backupChunkSize, data[:backupChunkSize])
+
+		_, err := service.Download(unsized, ioutil.Discard)
+
+		t.Run("it gives up after the maximum number of chunks", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+
+			if len(driver.Requests("get")) != 2 {
+				t.Errorf("Expected 2 requests, got %d", len(driver.Requests("get")))
+			}
+		})
+	})
+}
+
+func TestDatabaseBackupService_Resume(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewDatabaseBackupService(driver)
+	data := []byte("the whole backup")
+	backup := &DatabaseBackup{ID: 1, State: "completed", Size: int64(len(data)), Checksum: checksumOf(data)}
+
+	file, err := ioutil.TempFile("", "backup")
+	if err != nil {
+		t.Fatalf("Couldn't create file: %s", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	file.Write(data[:4])
+
+	t.Run("it downloads only the remainder", func(t *testing.T) {
+		stubBackupChunk(driver, backup, 4, data[4:])
+
+		total, err := service.Resume(backup, file)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if total != backup.Size {
+			t.Errorf("Expected %d bytes, got %d", backup.Size, total)
+		}
+
+		contents, _ := ioutil.ReadFile(file.Name())
+		if !bytes.Equal(contents, data) {
+			t.Errorf("Expected the file to contain the backup, got %q", contents)
+		}
+	})
+}
+
+func backupChunkPath(backup *DatabaseBackup, offset int) string {
+	return fmt.Sprintf(
+		"database_backups/%d/download?length=%s&offset=%d",
+		backup.ID,
+		strconv.Itoa(backupChunkSize),
+		offset,
+	)
+}
+
+func stubBackupChunk(driver *MockDriver, backup *DatabaseBackup, offset int, data []byte) {
+	for len(data) > 0 {
+		size := backupChunkSize
+		if len(data) < size {
+			size = len(data)
+		}
+
+		driver.AddResponse("get", backupChunkPath(backup, offset), Response{Pages: [][]byte{data[:size]}})
+
+		offset += size
+		data = data[size:]
+	}
+}
+
+func stubDatabaseBackups(driver *MockDriver, environment *Environment, backups ...*DatabaseBackup) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		DatabaseBackups []*DatabaseBackup `json:"database_backups,omitempty"`
+	}{DatabaseBackups: backups}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse(
+			"get",
+			fmt.Sprintf("environments/%d/database_backups", environment.ID),
+			Response{Pages: pages},
+		)
+	}
+}
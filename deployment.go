@@ -0,0 +1,225 @@
+package eygo
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Deployment is a data structure that models an application deployment on the
+// Engine Yard API.
+type Deployment struct {
+	ID             int    `json:"id,omitempty"`
+	Ref            string `json:"ref,omitempty"`
+	ResolvedRef    string `json:"resolved_ref,omitempty"`
+	Commit         string `json:"commit,omitempty"`
+	Migrate        bool   `json:"migrate,omitempty"`
+	MigrateCommand string `json:"migrate_command,omitempty"`
+	Successful     bool   `json:"successful,omitempty"`
+	Status         string `json:"status,omitempty"`
+	CreatedAt      string `json:"created_at,omitempty"`
+	StartedAt      string `json:"started_at,omitempty"`
+	FinishedAt     string `json:"finished_at,omitempty"`
+	UpdatedAt      string `json:"updated_at,omitempty"`
+	LogURL         string `json:"log,omitempty"`
+	ApplicationURL string `json:"application,omitempty"`
+	EnvironmentURL string `json:"environment,omitempty"`
+	UserURL        string `json:"user,omitempty"`
+}
+
+// DeploymentOptions is a data structure that describes the options that one
+// can pass along when deploying an Application.
+type DeploymentOptions struct {
+	Ref            string
+	Migrate        bool
+	MigrateCommand string
+}
+
+// DeploymentService is a repository one can use to create, retrieve, and
+// otherwise operate on Deployment records on the API.
+type DeploymentService struct {
+	Driver Driver
+}
+
+// NewDeploymentService returns a DeploymentService configured with the
+// provided Driver.
+func NewDeploymentService(driver Driver) *DeploymentService {
+	return &DeploymentService{Driver: driver}
+}
+
+// ForEnvironment returns an array of Deployment records that are both
+// associated with the given Environment and matching the given Params.
+func (service *DeploymentService) ForEnvironment(environment *Environment, params Params) []*Deployment {
+	return service.collection(
+		fmt.Sprintf("environments/%d/deployments", environment.ID),
+		params,
+	)
+}
+
+// ForApplication returns an array of Deployment records that are both
+// associated with the given Application and matching the given Params.
+func (service *DeploymentService) ForApplication(application *Application, params Params) []*Deployment {
+	return service.collection(
+		fmt.Sprintf("applications/%d/deployments", application.ID),
+		params,
+	)
+}
+
+// Find returns the Deployment record identified by the given deployment id. If
+// there are errors in retrieving this information, an error is returned as
+// well.
+func (service *DeploymentService) Find(id string) (*Deployment, error) {
+	response := service.Driver.Get("deployments/"+id, nil)
+	if response.Okay() {
+		wrapper := struct {
+			Deployment *Deployment `json:"deployment,omitempty"`
+		}{}
+
+		err := json.Unmarshal(response.Pages[0], &wrapper)
+		if err != nil {
+			return nil, err
+		}
+
+		return wrapper.Deployment, nil
+	}
+
+	return nil, response.Error
+}
+
+type deploymentRequest struct {
+	ApplicationID  int    `json:"app_id,omitempty"`
+	Ref            string `json:"ref,omitempty"`
+	Migrate        bool   `json:"migrate"`
+	MigrateCommand string `json:"migrate_command,omitempty"`
+}
+
+// Deploy takes an Environment, an Application, and DeploymentOptions, then
+// starts a deployment of the Application to the Environment. If there are
+// issues along the way, an error is returned. Otherwise, the new Deployment is
+// returned.
+func (service *DeploymentService) Deploy(environment *Environment, application *Application, options DeploymentOptions) (*Deployment, error) {
+	if environment == nil || environment.ID == 0 {
+		return nil, fmt.Errorf("No valid environment given")
+	}
+
+	if application == nil || application.ID == 0 {
+		return nil, fmt.Errorf("No valid application given")
+	}
+
+	if len(options.Ref) == 0 {
+		return nil, fmt.Errorf("No ref given")
+	}
+
+	if len(options.MigrateCommand) > 0 && !options.Migrate {
+		return nil, fmt.Errorf("A migrate command requires migrations to be enabled")
+	}
+
+	wrapper := struct {
+		Deployment *deploymentRequest `json:"deployment,omitempty"`
+	}{
+		Deployment: &deploymentRequest{
+			ApplicationID:  application.ID,
+			Ref:            options.Ref,
+			Migrate:        options.Migrate,
+			MigrateCommand: options.MigrateCommand,
+		},
+	}
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	response := service.Driver.Post(
+		fmt.Sprintf("environments/%d/deployments/deploy", environment.ID),
+		nil,
+		body,
+	)
+
+	return service.unwrap(response)
+}
+
+// Rollback takes an Environment and an Application, then asks the API to roll
+// the Application back to its previous release on the Environment. If there
+// are issues along the way, an error is returned. Otherwise, the Deployment
+// that performs the rollback is returned.
+func (service *DeploymentService) Rollback(environment *Environment, application *Application) (*Deployment, error) {
+	if environment == nil || environment.ID == 0 {
+		return nil, fmt.Errorf("No valid environment given")
+	}
+
+	if application == nil || application.ID == 0 {
+		return nil, fmt.Errorf("No valid application given")
+	}
+
+	wrapper := struct {
+		Deployment struct {
+			ApplicationID int `json:"app_id,omitempty"`
+		} `json:"deployment,omitempty"`
+	}{}
+	wrapper.Deployment.ApplicationID = application.ID
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	response := service.Driver.Post(
+		fmt.Sprintf("environments/%d/deployments/rollback", environment.ID),
+		nil,
+		body,
+	)
+
+	return service.unwrap(response)
+}
+
+func (service *DeploymentService) unwrap(response Response) (*Deployment, error) {
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		Deployment *Deployment `json:"deployment,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Deployment, nil
+}
+
+func (service *DeploymentService) collection(path string, params Params) []*Deployment {
+	deployments := make([]*Deployment, 0)
+	response := service.Driver.Get(path, params)
+
+	if response.Okay() {
+		for _, page := range response.Pages {
+			wrapper := struct {
+				Deployments []*Deployment `json:"deployments,omitempty"`
+			}{}
+
+			if err := json.Unmarshal(page, &wrapper); err == nil {
+				deployments = append(deployments, wrapper.Deployments...)
+			}
+		}
+	}
+
+	return deployments
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
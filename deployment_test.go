@@ -0,0 +1,400 @@
+package eygo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestNewDeploymentService(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewDeploymentService(driver)
+
+	t.Run("it is configured with the given driver", func(t *testing.T) {
+		if service.Driver != driver {
+			t.Errorf("Expected the service to use the given driver")
+		}
+	})
+}
+
+func TestDeploymentService_ForEnvironment(t *testing.T) {
+	environment := &Environment{ID: 1, Name: "Environment 1"}
+	driver := NewMockDriver()
+	service := NewDeploymentService(driver)
+
+	t.Run("when there are matching deployments", func(t *testing.T) {
+		deployment1 := &Deployment{ID: 1, Ref: "master"}
+		deployment2 := &Deployment{ID: 2, Ref: "master"}
+		deployment3 := &Deployment{ID: 3, Ref: "release"}
+
+		stubEnvironmentDeployments(driver, environment, deployment1, deployment2, deployment3)
+
+		all := service.ForEnvironment(environment, nil)
+
+		t.Run("it contains all matching deployments", func(t *testing.T) {
+			deployments := []*Deployment{deployment1, deployment2, deployment3}
+
+			if len(all) != len(deployments) {
+				t.Errorf("Expected %d deployments, got %d", len(deployments), len(all))
+			}
+
+			for _, deployment := range deployments {
+				found := false
+
+				for _, other := range all {
+					if deployment.ID == other.ID {
+						found = true
+					}
+				}
+
+				if !found {
+					t.Errorf("Deployment %d was not present", deployment.ID)
+				}
+			}
+		})
+
+	})
+
+	t.Run("when there are no matching deployments", func(t *testing.T) {
+		driver.Reset()
+
+		t.Run("it is empty", func(t *testing.T) {
+			all := service.ForEnvironment(environment, nil)
+
+			if len(all) != 0 {
+				t.Errorf("Expected 0 deployments, got %d", len(all))
+			}
+		})
+
+	})
+
+}
+
+func TestDeploymentService_ForApplication(t *testing.T) {
+	application := &Application{ID: 1, Name: "Application 1"}
+	driver := NewMockDriver()
+	service := NewDeploymentService(driver)
+
+	t.Run("when there are matching deployments", func(t *testing.T) {
+		deployment1 := &Deployment{ID: 1, Ref: "master"}
+		deployment2 := &Deployment{ID: 2, Ref: "master"}
+		deployment3 := &Deployment{ID: 3, Ref: "release"}
+
+		stubApplicationDeployments(driver, application, deployment1, deployment2, deployment3)
+
+		all := service.ForApplication(application, nil)
+
+		t.Run("it contains all matching deployments", func(t *testing.T) {
+			deployments := []*Deployment{deployment1, deployment2, deployment3}
+
+			if len(all) != len(deployments) {
+				t.Errorf("Expected %d deployments, got %d", len(deployments), len(all))
+			}
+
+			for _, deployment := range deployments {
+				found := false
+
+				for _, other := range all {
+					if deployment.ID == other.ID {
+						found = true
+					}
+				}
+
+				if !found {
+					t.Errorf("Deployment %d was not present", deployment.ID)
+				}
+			}
+		})
+
+	})
+
+	t.Run("when there are no matching deployments", func(t *testing.T) {
+		driver.Reset()
+
+		t.Run("it is empty", func(t *testing.T) {
+			all := service.ForApplication(application, nil)
+
+			if len(all) != 0 {
+				t.Errorf("Expected 0 deployments, got %d", len(all))
+			}
+		})
+
+	})
+
+}
+
+func TestDeploymentService_Find(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewDeploymentService(driver)
+	deployment := &Deployment{ID: 1, Ref: "master", Commit: "abc123"}
+	stubDeployment(driver, deployment)
+
+	t.Run("for a known deployment", func(t *testing.T) {
+		result, err := service.Find("1")
+
+		t.Run("it is the requested deployment", func(t *testing.T) {
+			if result.ID != deployment.ID {
+				t.Errorf("Expected deployment 1, got deployment %d", result.ID)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("for an unknown deployment", func(t *testing.T) {
+		result, err := service.Find("2")
+
+		t.Run("it returns no deployment", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no deployment, got deployment %d", result.ID)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestDeploymentService_Deploy(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewDeploymentService(driver)
+	environment := &Environment{ID: 1, Name: "Environment 1"}
+	application := &Application{ID: 2, Name: "Application 2"}
+	options := DeploymentOptions{Ref: "master", Migrate: true, MigrateCommand: "rake db:migrate"}
+	path := "environments/1/deployments/deploy"
+
+	t.Run("with an invalid environment", func(t *testing.T) {
+		driver.Reset()
+
+		result, err := service.Deploy(&Environment{}, application, options)
+
+		t.Run("it returns no deployment", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no deployment, got deployment %d", result.ID)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it makes no API call", func(t *testing.T) {
+			if len(driver.Requests("post")) != 0 {
+				t.Errorf("Expected no post requests")
+			}
+		})
+	})
+
+	t.Run("with an invalid application", func(t *testing.T) {
+		driver.Reset()
+
+		_, err := service.Deploy(environment, nil, options)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("without a ref", func(t *testing.T) {
+		driver.Reset()
+
+		_, err := service.Deploy(environment, application, DeploymentOptions{})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("with a migrate command but migrations disabled", func(t *testing.T) {
+		driver.Reset()
+
+		_, err := service.Deploy(
+			environment,
+			application,
+			DeploymentOptions{Ref: "master", MigrateCommand: "rake db:migrate"},
+		)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when the deploy is successful", func(t *testing.T) {
+		driver.Reset()
+
+		driver.AddResponse(
+			"post",
+			path,
+			Response{
+				Pages: [][]byte{
+					[]byte(`{"deployment": {"id": 3, "ref": "master", "migrate": true, "status": "running"}}`),
+				},
+			},
+		)
+
+		result, err := service.Deploy(environment, application, options)
+
+		t.Run("it returns the new deployment", func(t *testing.T) {
+			if result == nil {
+				t.Fatalf("Expected a deployment")
+			}
+
+			if result.ID != 3 || result.Ref != options.Ref || !result.Migrate {
+				t.Errorf("Expected deployment 3 of master with migrations, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when the deploy fails", func(t *testing.T) {
+		driver.Reset()
+
+		driver.AddResponse("post", path, Response{Error: fmt.Errorf("Oh no!")})
+
+		result, err := service.Deploy(environment, application, options)
+
+		t.Run("it returns no deployment", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no deployment, got deployment %d", result.ID)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestDeploymentService_Rollback(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewDeploymentService(driver)
+	environment := &Environment{ID: 1, Name: "Environment 1"}
+	application := &Application{ID: 2, Name: "Application 2"}
+	path := "environments/1/deployments/rollback"
+
+	t.Run("with an invalid application", func(t *testing.T) {
+		driver.Reset()
+
+		_, err := service.Rollback(environment, &Application{})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when the rollback is successful", func(t *testing.T) {
+		driver.Reset()
+
+		driver.AddResponse(
+			"post",
+			path,
+			Response{
+				Pages: [][]byte{
+					[]byte(`{"deployment": {"id": 4, "ref": "v1.0.0", "status": "running"}}`),
+				},
+			},
+		)
+
+		result, err := service.Rollback(environment, application)
+
+		t.Run("it returns the rollback deployment", func(t *testing.T) {
+			if result == nil {
+				t.Fatalf("Expected a deployment")
+			}
+
+			if result.ID != 4 {
+				t.Errorf("Expected deployment 4, got %d", result.ID)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when the rollback fails", func(t *testing.T) {
+		driver.Reset()
+
+		driver.AddResponse("post", path, Response{Error: fmt.Errorf("Oh no!")})
+
+		result, err := service.Rollback(environment, application)
+
+		t.Run("it returns no deployment", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no deployment, got deployment %d", result.ID)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func stubEnvironmentDeployments(driver *MockDriver, environment *Environment, deployments ...*Deployment) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		Deployments []*Deployment `json:"deployments,omitempty"`
+	}{Deployments: deployments}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "environments/"+strconv.Itoa(environment.ID)+"/deployments", Response{Pages: pages})
+	}
+}
+
+func stubApplicationDeployments(driver *MockDriver, application *Application, deployments ...*Deployment) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		Deployments []*Deployment `json:"deployments,omitempty"`
+	}{Deployments: deployments}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "applications/"+strconv.Itoa(application.ID)+"/deployments", Response{Pages: pages})
+	}
+}
+
+func stubDeployment(driver *MockDriver, deployment *Deployment) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		Deployment *Deployment `json:"deployment,omitempty"`
+	}{Deployment: deployment}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "deployments/"+strconv.Itoa(deployment.ID), Response{Pages: pages})
+	}
+}
@@ -0,0 +1,123 @@
+package eygo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseDotenv reads variables in the dotenv format from the given reader and
+// returns them as a map. Blank lines and lines starting with # are ignored,
+// an optional "export " prefix is allowed, values may be wrapped in single
+// quotes (taken literally) or double quotes (which understand \n, \t, \", and
+// \\ escapes), and unquoted values end at a " #" comment. If a line can't be
+// parsed, an error naming the line is returned.
+func ParseDotenv(reader io.Reader) (map[string]string, error) {
+	variables := make(map[string]string)
+	scanner := bufio.NewScanner(reader)
+	number := 0
+
+	for scanner.Scan() {
+		number++
+		line := strings.TrimSpace(scanner.Text())
+
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		separator := strings.Index(line, "=")
+		if separator < 0 {
+			return nil, fmt.Errorf("Line %d: expected NAME=value", number)
+		}
+
+		name := strings.TrimSpace(line[:separator])
+		if !variableNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("Line %d: invalid variable name %q", number, name)
+		}
+
+		value, err := dotenvValue(strings.TrimSpace(line[separator+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("Line %d: %s", number, err)
+		}
+
+		variables[name] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return variables, nil
+}
+
+func dotenvValue(raw string) (string, error) {
+	if len(raw) == 0 {
+		return "", nil
+	}
+
+	switch raw[0] {
+	case '\'':
+		end := strings.Index(raw[1:], "'")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated single-quoted value")
+		}
+
+		return raw[1 : end+1], nil
+
+	case '"':
+		var value strings.Builder
+
+		for i := 1; i < len(raw); i++ {
+			switch raw[i] {
+			case '"':
+				return value.String(), nil
+			case '\\':
+				if i+1 == len(raw) {
+					return "", fmt.Errorf("unterminated double-quoted value")
+				}
+
+				i++
+				switch raw[i] {
+				case 'n':
+					value.WriteByte('\n')
+				case 't':
+					value.WriteByte('\t')
+				default:
+					value.WriteByte(raw[i])
+				}
+			default:
+				value.WriteByte(raw[i])
+			}
+		}
+
+		return "", fmt.Errorf("unterminated double-quoted value")
+	}
+
+	if comment := strings.Index(raw, " #"); comment >= 0 {
+		raw = raw[:comment]
+	}
+
+	return strings.TrimSpace(raw), nil
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
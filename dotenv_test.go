@@ -0,0 +1,58 @@
+package eygo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	t.Run("with a valid file", func(t *testing.T) {
+		input := strings.Join([]string{
+			"# a comment",
+			"",
+			"PLAIN=value",
+			"export EXPORTED=yes",
+			"COMMENTED=value # trailing comment",
+			`DOUBLE="line one\nline two"`,
+			`SINGLE='literal \n # not a comment'`,
+			"EMPTY=",
+		}, "\n")
+
+		variables, err := ParseDotenv(strings.NewReader(input))
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it parses every variable", func(t *testing.T) {
+			expected := map[string]string{
+				"PLAIN":     "value",
+				"EXPORTED":  "yes",
+				"COMMENTED": "value",
+				"DOUBLE":    "line one\nline two",
+				"SINGLE":    `literal \n # not a comment`,
+				"EMPTY":     "",
+			}
+
+			if len(variables) != len(expected) {
+				t.Errorf("Expected %d variables, got %d", len(expected), len(variables))
+			}
+
+			for name, value := range expected {
+				if variables[name] != value {
+					t.Errorf("Expected %s to be %q, got %q", name, value, variables[name])
+				}
+			}
+		})
+	})
+
+	t.Run("with an invalid file", func(t *testing.T) {
+		for _, input := range []string{"NOEQUALS", "1BAD=value", `OPEN="unterminated`} {
+			if _, err := ParseDotenv(strings.NewReader(input)); err == nil {
+				t.Errorf("Expected an error for %q", input)
+			}
+		}
+	})
+}
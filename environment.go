@@ -82,23 +82,30 @@ func (service *EnvironmentService) ForAccount(account *Account, params Params) [
 }
 
 func (service *EnvironmentService) collection(path string, params Params) []*Environment {
+	environments, _ := service.fetch(path, params)
+
+	return environments
+}
+
+func (service *EnvironmentService) fetch(path string, params Params) ([]*Environment, error) {
 	environments := make([]*Environment, 0)
 	response := service.Driver.Get(path, params)
 
-	if response.Okay() {
-		for _, page := range response.Pages {
-			wrapper := struct {
-				Environments []*Environment `json:"environments,omitempty"`
-			}{}
+	if !response.Okay() {
+		return environments, response.Error
+	}
+
+	for _, page := range response.Pages {
+		wrapper := struct {
+			Environments []*Environment `json:"environments,omitempty"`
+		}{}
 
-			if err := json.Unmarshal(page, &wrapper); err == nil {
-				environments = append(environments, wrapper.Environments...)
-			}
+		if err := json.Unmarshal(page, &wrapper); err == nil {
+			environments = append(environments, wrapper.Environments...)
 		}
 	}
 
-	return environments
-
+	return environments, nil
 }
 
 /*
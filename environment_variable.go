@@ -0,0 +1,418 @@
+package eygo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// EnvironmentVariable is a data structure that models a custom environment
+// variable that the Engine Yard API sets for an application within an
+// environment.
+type EnvironmentVariable struct {
+	ID             int    `json:"id,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Value          string `json:"value,omitempty"`
+	Sensitive      bool   `json:"sensitive,omitempty"`
+	EnvironmentURL string `json:"environment,omitempty"`
+	ApplicationURL string `json:"application,omitempty"`
+	CreatedAt      string `json:"created_at,omitempty"`
+	UpdatedAt      string `json:"updated_at,omitempty"`
+}
+
+// String returns a representation of the variable in which the value is
+// masked if the variable is sensitive.
+func (variable EnvironmentVariable) String() string {
+	value := variable.Value
+	if variable.Sensitive {
+		value = redact(value)
+	}
+
+	return fmt.Sprintf("%s=%s", variable.Name, value)
+}
+
+// GoString returns a Go-syntax representation of the variable in which the
+// value is masked if the variable is sensitive.
+func (variable EnvironmentVariable) GoString() string {
+	value := variable.Value
+	if variable.Sensitive {
+		value = redact(value)
+	}
+
+	return fmt.Sprintf(
+		"eygo.EnvironmentVariable{ID:%d, Name:%q, Value:%q, Sensitive:%t, EnvironmentURL:%q, ApplicationURL:%q, CreatedAt:%q, UpdatedAt:%q}",
+		variable.ID,
+		variable.Name,
+		value,
+		variable.Sensitive,
+		variable.EnvironmentURL,
+		variable.ApplicationURL,
+		variable.CreatedAt,
+		variable.UpdatedAt,
+	)
+}
+
+// VariableDiff is a data structure that describes the differences between a
+// desired set of variables and the variables that are actually set.
+type VariableDiff struct {
+	// Add lists the desired variables that aren't set.
+	Add []*EnvironmentVariable
+
+	// Change lists the set variables whose values differ from the desired
+	// ones, with the desired values. Sensitive variables whose values the
+	// API doesn't reveal are always listed here.
+	Change []*EnvironmentVariable
+
+	// Remove lists the set variables that aren't desired.
+	Remove []*EnvironmentVariable
+
+	// Unchanged lists the set variables that already have the desired values.
+	Unchanged []*EnvironmentVariable
+}
+
+// Empty returns true if the actual variables already match the desired ones,
+// and false otherwise.
+func (diff *VariableDiff) Empty() bool {
+	return len(diff.Add) == 0 && len(diff.Change) == 0 && len(diff.Remove) == 0
+}
+
+// DiffVariables compares the given desired variables with the given actual
+// variables and returns a VariableDiff describing how they differ. Each list
+// in the diff is ordered by name.
+func DiffVariables(desired map[string]string, actual []*EnvironmentVariable) *VariableDiff {
+	diff := &VariableDiff{
+		Add:       make([]*EnvironmentVariable, 0),
+		Change:    make([]*EnvironmentVariable, 0),
+		Remove:    make([]*EnvironmentVariable, 0),
+		Unchanged: make([]*EnvironmentVariable, 0),
+	}
+
+	existing := make(map[string]*EnvironmentVariable)
+
+	for _, variable := range actual {
+		existing[variable.Name] = variable
+
+		value, wanted := desired[variable.Name]
+
+		switch {
+		case !wanted:
+			diff.Remove = append(diff.Remove, variable)
+		case variable.Value != value || (variable.Sensitive && len(variable.Value) == 0):
+			changed := *variable
+			changed.Value = value
+			diff.Change = append(diff.Change, &changed)
+		default:
+			diff.Unchanged = append(diff.Unchanged, variable)
+		}
+	}
+
+	for name, value := range desired {
+		if existing[name] == nil {
+			diff.Add = append(diff.Add, &EnvironmentVariable{Name: name, Value: value})
+		}
+	}
+
+	for _, list := range [][]*EnvironmentVariable{diff.Add, diff.Change, diff.Remove, diff.Unchanged} {
+		sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	}
+
+	return diff
+}
+
+// EnvironmentVariableService is a repository one can use to retrieve and save
+// EnvironmentVariable records on the API.
+type EnvironmentVariableService struct {
+	Driver Driver
+}
+
+// NewEnvironmentVariableService returns an EnvironmentVariableService
+// configured to use the provided Driver.
+func NewEnvironmentVariableService(driver Driver) *EnvironmentVariableService {
+	return &EnvironmentVariableService{Driver: driver}
+}
+
+// ForEnvironment returns an array of EnvironmentVariables that are both
+// associated with the given Environment and matching the given Params.
+func (service *EnvironmentVariableService) ForEnvironment(environment *Environment, params Params) []*EnvironmentVariable {
+	return service.collection(service.scope(params, environment, nil))
+}
+
+// ForApplication returns an array of EnvironmentVariables that are both
+// associated with the given Application and matching the given Params.
+func (service *EnvironmentVariableService) ForApplication(application *Application, params Params) []*EnvironmentVariable {
+	return service.collection(service.scope(params, nil, application))
+}
+
+// List returns an array of the EnvironmentVariables set for the given
+// Application within the given Environment.
+func (service *EnvironmentVariableService) List(environment *Environment, application *Application) []*EnvironmentVariable {
+	return service.collection(service.scope(nil, environment, application))
+}
+
+// Set saves the given EnvironmentVariable for the given Application within
+// the given Environment on the upstream API, updating the variable with the
+// same name if there is one and creating it otherwise. If there are issues
+// along the way, including when the current variables can't be retrieved, an
+// error is returned. Otherwise, the saved EnvironmentVariable is returned.
+func (service *EnvironmentVariableService) Set(environment *Environment, application *Application, variable *EnvironmentVariable) (*EnvironmentVariable, error) {
+	if err := validScope(environment, application); err != nil {
+		return nil, err
+	}
+
+	if variable == nil || !variableNamePattern.MatchString(variable.Name) {
+		return nil, fmt.Errorf("No valid variable name given")
+	}
+
+	current, err := service.fetch(service.scope(nil, environment, application))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existing := range current {
+		if existing.Name == variable.Name {
+			updated := *variable
+			updated.ID = existing.ID
+
+			return service.update(&updated)
+		}
+	}
+
+	return service.create(environment, application, variable)
+}
+
+// Upsert sets each of the given variables for the given Application within
+// the given Environment, creating the ones that are missing and updating the
+// ones whose values differ. Variables that are set but not given are left
+// alone. New variables are marked sensitive if sensitive is true. The diff
+// that was applied is returned; if any variable can't be saved, an error is
+// returned along with it. If the current variables can't be retrieved,
+// nothing is saved and an error is returned.
+func (service *EnvironmentVariableService) Upsert(environment *Environment, application *Application, variables map[string]string, sensitive bool) (*VariableDiff, error) {
+	if err := validScope(environment, application); err != nil {
+		return nil, err
+	}
+
+	for name := range variables {
+		if !variableNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("Invalid variable name %q", name)
+		}
+	}
+
+	current, err := service.fetch(service.scope(nil, environment, application))
+	if err != nil {
+		return nil, err
+	}
+
+	diff := DiffVariables(variables, current)
+	failures := make([]string, 0)
+
+	for _, variable := range diff.Add {
+		variable.Sensitive = sensitive
+
+		if _, err := service.create(environment, application, variable); err != nil {
+			failures = append(failures, variable.Name)
+		}
+	}
+
+	for _, variable := range diff.Change {
+		if _, err := service.update(variable); err != nil {
+			failures = append(failures, variable.Name)
+		}
+	}
+
+	if len(failures) > 0 {
+		return diff, fmt.Errorf("Couldn't save variables: %v", failures)
+	}
+
+	return diff, nil
+}
+
+// UpsertDotenv reads variables in the dotenv format from the given reader and
+// upserts them as Upsert does.
+func (service *EnvironmentVariableService) UpsertDotenv(environment *Environment, application *Application, reader io.Reader, sensitive bool) (*VariableDiff, error) {
+	variables, err := ParseDotenv(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.Upsert(environment, application, variables, sensitive)
+}
+
+// Diff compares the given desired variables with the variables that are set
+// for the given Application within the given Environment. If the current
+// variables can't be retrieved, an error is returned.
+func (service *EnvironmentVariableService) Diff(environment *Environment, application *Application, desired map[string]string) (*VariableDiff, error) {
+	if err := validScope(environment, application); err != nil {
+		return nil, err
+	}
+
+	current, err := service.fetch(service.scope(nil, environment, application))
+	if err != nil {
+		return nil, err
+	}
+
+	return DiffVariables(desired, current), nil
+}
+
+// MarkSensitive sets whether the given EnvironmentVariable is sensitive on
+// the upstream API. The values of sensitive variables are hidden from the
+// dashboard and deploy logs. If there are issues along the way, an error is
+// returned. Otherwise, the updated EnvironmentVariable is returned.
+func (service *EnvironmentVariableService) MarkSensitive(variable *EnvironmentVariable, sensitive bool) (*EnvironmentVariable, error) {
+	if variable == nil || variable.ID == 0 {
+		return nil, fmt.Errorf("can't update a variable without an ID")
+	}
+
+	body, err := service.encode(map[string]interface{}{"sensitive": sensitive})
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Put("environment_variables/"+strconv.Itoa(variable.ID), nil, body),
+	)
+}
+
+// Delete deletes the given EnvironmentVariable from the upstream API. If
+// there are issues along the way, an error is returned.
+func (service *EnvironmentVariableService) Delete(variable *EnvironmentVariable) error {
+	if variable == nil || variable.ID == 0 {
+		return fmt.Errorf("No valid variable given")
+	}
+
+	response := service.Driver.Delete("environment_variables/"+strconv.Itoa(variable.ID), Params{})
+	if !response.Okay() {
+		return response.Error
+	}
+
+	return nil
+}
+
+func (service *EnvironmentVariableService) create(environment *Environment, application *Application, variable *EnvironmentVariable) (*EnvironmentVariable, error) {
+	body, err := service.encode(map[string]interface{}{
+		"name":        variable.Name,
+		"value":       variable.Value,
+		"sensitive":   variable.Sensitive,
+		"environment": environment.ID,
+		"application": application.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(service.Driver.Post("environment_variables", nil, body))
+}
+
+func (service *EnvironmentVariableService) update(variable *EnvironmentVariable) (*EnvironmentVariable, error) {
+	body, err := service.encode(map[string]interface{}{
+		"value":     variable.Value,
+		"sensitive": variable.Sensitive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Put("environment_variables/"+strconv.Itoa(variable.ID), nil, body),
+	)
+}
+
+func (service *EnvironmentVariableService) scope(params Params, environment *Environment, application *Application) Params {
+	scoped := Params{}
+
+	for key, values := range params {
+		scoped[key] = values
+	}
+
+	if environment != nil {
+		scoped.Set("environment", strconv.Itoa(environment.ID))
+	}
+
+	if application != nil {
+		scoped.Set("application", strconv.Itoa(application.ID))
+	}
+
+	return scoped
+}
+
+func validScope(environment *Environment, application *Application) error {
+	if environment == nil || environment.ID == 0 {
+		return fmt.Errorf("No valid environment given")
+	}
+
+	if application == nil || application.ID == 0 {
+		return fmt.Errorf("No valid application given")
+	}
+
+	return nil
+}
+
+func (service *EnvironmentVariableService) encode(params map[string]interface{}) ([]byte, error) {
+	wrapper := struct {
+		EnvironmentVariable map[string]interface{} `json:"environment_variable"`
+	}{EnvironmentVariable: params}
+
+	return json.Marshal(&wrapper)
+}
+
+func (service *EnvironmentVariableService) unwrap(response Response) (*EnvironmentVariable, error) {
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		EnvironmentVariable *EnvironmentVariable `json:"environment_variable,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.EnvironmentVariable, nil
+}
+
+func (service *EnvironmentVariableService) collection(params Params) []*EnvironmentVariable {
+	variables, _ := service.fetch(params)
+
+	return variables
+}
+
+func (service *EnvironmentVariableService) fetch(params Params) ([]*EnvironmentVariable, error) {
+	variables := make([]*EnvironmentVariable, 0)
+	response := service.Driver.Get("environment_variables", params)
+
+	if !response.Okay() {
+		return variables, response.Error
+	}
+
+	for _, page := range response.Pages {
+		wrapper := struct {
+			EnvironmentVariables []*EnvironmentVariable `json:"environment_variables,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(page, &wrapper); err == nil {
+			variables = append(variables, wrapper.EnvironmentVariables...)
+		}
+	}
+
+	return variables, nil
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
@@ -0,0 +1,478 @@
+package eygo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEnvironmentVariable_String(t *testing.T) {
+	t.Run("it shows the value of a plain variable", func(t *testing.T) {
+		variable := EnvironmentVariable{Name: "PLAIN", Value: "value"}
+
+		if variable.String() != "PLAIN=value" {
+			t.Errorf("Expected PLAIN=value, got %s", variable.String())
+		}
+	})
+
+	t.Run("it masks the value of a sensitive variable", func(t *testing.T) {
+		variable := EnvironmentVariable{Name: "SECRET", Value: "hunter2", Sensitive: true}
+
+		if strings.Contains(variable.String(), "hunter2") {
+			t.Errorf("Expected the value to be masked, got %s", variable.String())
+		}
+	})
+}
+
+func TestEnvironmentVariable_GoString(t *testing.T) {
+	t.Run("it shows the value of a plain variable", func(t *testing.T) {
+		variable := &EnvironmentVariable{Name: "PLAIN", Value: "value"}
+
+		if !strings.Contains(fmt.Sprintf("%#v", variable), `Value:"value"`) {
+			t.Errorf("Expected the value to be shown, got %#v", variable)
+		}
+	})
+
+	t.Run("it masks the value of a sensitive variable", func(t *testing.T) {
+		variable := &EnvironmentVariable{Name: "SECRET", Value: "hunter2", Sensitive: true}
+
+		if formatted := fmt.Sprintf("%#v", variable); strings.Contains(formatted, "hunter2") {
+			t.Errorf("Expected the value to be masked, got %s", formatted)
+		}
+	})
+}
+
+func TestDiffVariables(t *testing.T) {
+	actual := []*EnvironmentVariable{
+		{ID: 1, Name: "SAME", Value: "same"},
+		{ID: 2, Name: "CHANGED", Value: "old"},
+		{ID: 3, Name: "EXTRA", Value: "extra"},
+		{ID: 4, Name: "HIDDEN", Sensitive: true},
+	}
+
+	desired := map[string]string{
+		"SAME":    "same",
+		"CHANGED": "new",
+		"HIDDEN":  "secret",
+		"NEW":     "new",
+	}
+
+	diff := DiffVariables(desired, actual)
+
+	t.Run("it adds missing variables", func(t *testing.T) {
+		if len(diff.Add) != 1 || diff.Add[0].Name != "NEW" {
+			t.Errorf("Expected NEW to be added, got %v", diff.Add)
+		}
+	})
+
+	t.Run("it changes differing and hidden sensitive variables", func(t *testing.T) {
+		if len(diff.Change) != 2 {
+			t.Fatalf("Expected 2 changes, got %d", len(diff.Change))
+		}
+
+		if diff.Change[0].Name != "CHANGED" || diff.Change[0].Value != "new" || diff.Change[0].ID != 2 {
+			t.Errorf("Expected CHANGED to become new, got %v", diff.Change[0])
+		}
+
+		if diff.Change[1].Name != "HIDDEN" {
+			t.Errorf("Expected HIDDEN to be changed, got %v", diff.Change[1])
+		}
+	})
+
+	t.Run("it doesn't modify the actual variables", func(t *testing.T) {
+		if actual[1].Value != "old" {
+			t.Errorf("Expected the actual value to be untouched")
+		}
+	})
+
+	t.Run("it removes undesired variables", func(t *testing.T) {
+		if len(diff.Remove) != 1 || diff.Remove[0].Name != "EXTRA" {
+			t.Errorf("Expected EXTRA to be removed, got %v", diff.Remove)
+		}
+	})
+
+	t.Run("it leaves matching variables alone", func(t *testing.T) {
+		if len(diff.Unchanged) != 1 || diff.Unchanged[0].Name != "SAME" {
+			t.Errorf("Expected SAME to be unchanged, got %v", diff.Unchanged)
+		}
+	})
+
+	t.Run("it is empty when nothing differs", func(t *testing.T) {
+		if diff.Empty() {
+			t.Errorf("Expected the diff not to be empty")
+		}
+
+		if !DiffVariables(map[string]string{"SAME": "same"}, actual[:1]).Empty() {
+			t.Errorf("Expected the diff to be empty")
+		}
+	})
+}
+
+func TestEnvironmentVariableService_List(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewEnvironmentVariableService(driver)
+	environment := &Environment{ID: 1}
+	application := &Application{ID: 2}
+
+	t.Run("it scopes the request to the environment and application", func(t *testing.T) {
+		stubEnvironmentVariables(driver, environment, application,
+			&EnvironmentVariable{ID: 1, Name: "ONE"},
+			&EnvironmentVariable{ID: 2, Name: "TWO"},
+		)
+
+		all := service.List(environment, application)
+
+		if len(all) != 2 {
+			t.Errorf("Expected 2 variables, got %d", len(all))
+		}
+	})
+
+	t.Run("it is empty when the request fails", func(t *testing.T) {
+		driver.Reset()
+
+		if len(service.List(environment, application)) != 0 {
+			t.Errorf("Expected no variables")
+		}
+	})
+
+	t.Run("it can be scoped to just an environment", func(t *testing.T) {
+		driver.Reset()
+		service.ForEnvironment(environment, Params{"name": {"ONE"}})
+
+		requests := driver.Requests("get")
+		if len(requests) != 1 || requests[0] != "environment_variables?environment=1&name=ONE" {
+			t.Errorf("Unexpected requests %v", requests)
+		}
+	})
+
+	t.Run("it can be scoped to just an application", func(t *testing.T) {
+		driver.Reset()
+		service.ForApplication(application, nil)
+
+		requests := driver.Requests("get")
+		if len(requests) != 1 || requests[0] != "environment_variables?application=2" {
+			t.Errorf("Unexpected requests %v", requests)
+		}
+	})
+}
+
+func TestEnvironmentVariableService_Set(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewEnvironmentVariableService(driver)
+	environment := &Environment{ID: 1}
+	application := &Application{ID: 2}
+
+	t.Run("with an invalid scope or name", func(t *testing.T) {
+		if _, err := service.Set(nil, application, &EnvironmentVariable{Name: "OK"}); err == nil {
+			t.Errorf("Expected an error without an environment")
+		}
+
+		if _, err := service.Set(environment, nil, &EnvironmentVariable{Name: "OK"}); err == nil {
+			t.Errorf("Expected an error without an application")
+		}
+
+		if _, err := service.Set(environment, application, &EnvironmentVariable{Name: "NOT OK"}); err == nil {
+			t.Errorf("Expected an error with an invalid name")
+		}
+
+		if len(driver.Requests("get")) != 0 {
+			t.Errorf("Expected no requests")
+		}
+	})
+
+	t.Run("when the variable is new", func(t *testing.T) {
+		driver.Reset()
+		stubEnvironmentVariables(driver, environment, application)
+		stubEnvironmentVariable(driver, "post", "environment_variables", &EnvironmentVariable{ID: 5, Name: "NEW"})
+
+		result, err := service.Set(environment, application, &EnvironmentVariable{Name: "NEW", Value: "value"})
+
+		t.Run("it creates the variable", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if result.ID != 5 {
+				t.Errorf("Expected the created variable, got %v", result)
+			}
+
+			body := string(driver.Bodies("post")[0])
+			for _, part := range []string{`"name":"NEW"`, `"value":"value"`, `"environment":1`, `"application":2`} {
+				if !strings.Contains(body, part) {
+					t.Errorf("Expected %s in body %s", part, body)
+				}
+			}
+		})
+	})
+
+	t.Run("when the variable exists", func(t *testing.T) {
+		driver.Reset()
+		stubEnvironmentVariables(driver, environment, application, &EnvironmentVariable{ID: 7, Name: "OLD"})
+		stubEnvironmentVariable(driver, "put", "environment_variables/7", &EnvironmentVariable{ID: 7, Name: "OLD"})
+
+		_, err := service.Set(environment, application, &EnvironmentVariable{Name: "OLD", Value: "value"})
+
+		t.Run("it updates the variable", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+
+			if len(driver.Requests("post")) != 0 || len(driver.Requests("put")) != 1 {
+				t.Errorf("Expected a single put request")
+			}
+		})
+	})
+
+	t.Run("when the variables can't be retrieved", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("get", "environment_variables?application=2&environment=1", Response{Error: fmt.Errorf("Oh no!")})
+		stubEnvironmentVariable(driver, "post", "environment_variables", &EnvironmentVariable{ID: 5, Name: "OLD"})
+
+		_, err := service.Set(environment, application, &EnvironmentVariable{Name: "OLD", Value: "value"})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it doesn't save the variable", func(t *testing.T) {
+			if len(driver.Requests("post")) != 0 || len(driver.Requests("put")) != 0 {
+				t.Errorf("Expected no writes")
+			}
+		})
+	})
+}
+
+func TestEnvironmentVariableService_Upsert(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewEnvironmentVariableService(driver)
+	environment := &Environment{ID: 1}
+	application := &Application{ID: 2}
+
+	t.Run("with an invalid name", func(t *testing.T) {
+		if _, err := service.Upsert(environment, application, map[string]string{"BAD NAME": "x"}, false); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when all saves succeed", func(t *testing.T) {
+		driver.Reset()
+		stubEnvironmentVariables(driver, environment, application,
+			&EnvironmentVariable{ID: 1, Name: "SAME", Value: "same"},
+			&EnvironmentVariable{ID: 2, Name: "CHANGED", Value: "old"},
+			&EnvironmentVariable{ID: 3, Name: "EXTRA", Value: "extra"},
+		)
+		stubEnvironmentVariable(driver, "post", "environment_variables", &EnvironmentVariable{ID: 4, Name: "NEW"})
+		stubEnvironmentVariable(driver, "put", "environment_variables/2", &EnvironmentVariable{ID: 2, Name: "CHANGED"})
+
+		diff, err := service.Upsert(environment, application, map[string]string{
+			"SAME":    "same",
+			"CHANGED": "new",
+			"NEW":     "new",
+		}, true)
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it only saves what differs", func(t *testing.T) {
+			if len(driver.Requests("post")) != 1 || len(driver.Requests("put")) != 1 {
+				t.Errorf("Expected one post and one put")
+			}
+
+			if !strings.Contains(string(driver.Bodies("post")[0]), `"sensitive":true`) {
+				t.Errorf("Expected the new variable to be sensitive")
+			}
+		})
+
+		t.Run("it leaves extra variables alone", func(t *testing.T) {
+			if len(driver.Requests("delete")) != 0 {
+				t.Errorf("Expected no deletions")
+			}
+
+			if len(diff.Remove) != 1 {
+				t.Errorf("Expected the extra variable in the diff")
+			}
+		})
+	})
+
+	t.Run("when a save fails", func(t *testing.T) {
+		driver.Reset()
+		stubEnvironmentVariables(driver, environment, application)
+		driver.AddResponse("post", "environment_variables", Response{Error: fmt.Errorf("Oh no!")})
+
+		_, err := service.Upsert(environment, application, map[string]string{"NEW": "new"}, false)
+
+		t.Run("it returns an error naming the variable", func(t *testing.T) {
+			if err == nil || !strings.Contains(err.Error(), "NEW") {
+				t.Errorf("Expected an error naming NEW, got %v", err)
+			}
+		})
+	})
+
+	t.Run("when the variables can't be retrieved", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("get", "environment_variables?application=2&environment=1", Response{Error: fmt.Errorf("Oh no!")})
+		stubEnvironmentVariable(driver, "post", "environment_variables", &EnvironmentVariable{ID: 4, Name: "NEW"})
+
+		diff, err := service.Upsert(environment, application, map[string]string{"NEW": "new"}, false)
+
+		t.Run("it returns an error and no diff", func(t *testing.T) {
+			if err == nil || diff != nil {
+				t.Errorf("Expected only an error, got %v", diff)
+			}
+		})
+
+		t.Run("it doesn't save anything", func(t *testing.T) {
+			if len(driver.Requests("post")) != 0 || len(driver.Requests("put")) != 0 {
+				t.Errorf("Expected no writes")
+			}
+		})
+	})
+}
+
+func TestEnvironmentVariableService_UpsertDotenv(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewEnvironmentVariableService(driver)
+	environment := &Environment{ID: 1}
+	application := &Application{ID: 2}
+
+	t.Run("with an invalid file", func(t *testing.T) {
+		if _, err := service.UpsertDotenv(environment, application, strings.NewReader("NOPE"), false); err == nil {
+			t.Errorf("Expected an error")
+		}
+
+		if len(driver.Requests("get")) != 0 {
+			t.Errorf("Expected no requests")
+		}
+	})
+
+	t.Run("with a valid file", func(t *testing.T) {
+		stubEnvironmentVariables(driver, environment, application)
+		stubEnvironmentVariable(driver, "post", "environment_variables", &EnvironmentVariable{ID: 1, Name: "FROM_FILE"})
+
+		diff, err := service.UpsertDotenv(environment, application, strings.NewReader("FROM_FILE=yes\n"), false)
+
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+
+		if len(diff.Add) != 1 || diff.Add[0].Name != "FROM_FILE" {
+			t.Errorf("Expected FROM_FILE to be added")
+		}
+	})
+}
+
+func TestEnvironmentVariableService_Diff(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewEnvironmentVariableService(driver)
+	environment := &Environment{ID: 1}
+	application := &Application{ID: 2}
+
+	t.Run("when the variables can be retrieved", func(t *testing.T) {
+		stubEnvironmentVariables(driver, environment, application, &EnvironmentVariable{ID: 1, Name: "OLD", Value: "old"})
+
+		diff, err := service.Diff(environment, application, map[string]string{"NEW": "new"})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if len(diff.Add) != 1 || len(diff.Remove) != 1 {
+			t.Errorf("Unexpected diff: %v", diff)
+		}
+	})
+
+	t.Run("when the variables can't be retrieved", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("get", "environment_variables?application=2&environment=1", Response{Error: fmt.Errorf("Oh no!")})
+
+		if _, err := service.Diff(environment, application, map[string]string{"NEW": "new"}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestEnvironmentVariableService_MarkSensitive(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewEnvironmentVariableService(driver)
+
+	t.Run("without an ID", func(t *testing.T) {
+		if _, err := service.MarkSensitive(&EnvironmentVariable{}, true); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when successful", func(t *testing.T) {
+		stubEnvironmentVariable(driver, "put", "environment_variables/3", &EnvironmentVariable{ID: 3, Sensitive: true})
+
+		result, err := service.MarkSensitive(&EnvironmentVariable{ID: 3}, true)
+
+		if err != nil || !result.Sensitive {
+			t.Errorf("Expected a sensitive variable, got %v (%v)", result, err)
+		}
+
+		if string(driver.Bodies("put")[0]) != `{"environment_variable":{"sensitive":true}}` {
+			t.Errorf("Unexpected body %s", driver.Bodies("put")[0])
+		}
+	})
+}
+
+func TestEnvironmentVariableService_Delete(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewEnvironmentVariableService(driver)
+	variable := &EnvironmentVariable{ID: 3}
+
+	t.Run("without an ID", func(t *testing.T) {
+		if err := service.Delete(&EnvironmentVariable{}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when successful", func(t *testing.T) {
+		driver.AddResponse("delete", "environment_variables/3", Response{Pages: [][]byte{[]byte(`{}`)}})
+
+		if err := service.Delete(variable); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("when unsuccessful", func(t *testing.T) {
+		driver.AddResponse("delete", "environment_variables/3", Response{Error: fmt.Errorf("Oh no!")})
+
+		if err := service.Delete(variable); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func stubEnvironmentVariables(driver *MockDriver, environment *Environment, application *Application, variables ...*EnvironmentVariable) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		EnvironmentVariables []*EnvironmentVariable `json:"environment_variables"`
+	}{EnvironmentVariables: variables}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse(
+			"get",
+			fmt.Sprintf("environment_variables?application=%d&environment=%d", application.ID, environment.ID),
+			Response{Pages: pages},
+		)
+	}
+}
+
+func stubEnvironmentVariable(driver *MockDriver, method string, path string, variable *EnvironmentVariable) {
+	wrapper := struct {
+		EnvironmentVariable *EnvironmentVariable `json:"environment_variable"`
+	}{EnvironmentVariable: variable}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		driver.AddResponse(method, path, Response{Pages: [][]byte{encoded}})
+	}
+}
@@ -2,6 +2,13 @@
 // interacting with version 3 of the Engine Yard Core API programmatically.
 package eygo
 
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
 // Driver is an interface that defines the minimal API to perform low-level
 // operations on the upstream Engine Yard REST API.
 type Driver interface {
@@ -37,6 +44,38 @@ func (params Params) Set(key string, value string) {
 	params[key] = []string{value}
 }
 
+// pathFor takes a resource URL as reported by the API (for example, the
+// LatestChefLogURL of a Server) and returns the path portion of it in the form
+// that Driver methods expect.
+func pathFor(resource string) string {
+	parsed, err := url.Parse(resource)
+	if err != nil || len(parsed.Host) == 0 {
+		return strings.TrimPrefix(resource, "/")
+	}
+
+	return strings.TrimPrefix(parsed.Path, "/")
+}
+
+var timestampLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05",
+}
+
+// parseTimestamp takes one of the timestamp strings reported by the API and
+// returns the time.Time that it represents.
+func parseTimestamp(value string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("Unrecognized timestamp: %s", value)
+}
+
 /*
 Copyright 2018 Dennis Walters
 
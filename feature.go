@@ -38,6 +38,14 @@ func (service *FeatureService) ForAccount(account *Account, params Params) []*Fe
 
 // Enable turns the given Feature on for the Account in question.
 func (service *FeatureService) Enable(account *Account, feature *Feature) error {
+	if feature == nil || len(feature.ID) == 0 {
+		return fmt.Errorf("No valid feature given")
+	}
+
+	if account == nil || len(account.ID) == 0 {
+		return fmt.Errorf("No valid account given")
+	}
+
 	params := Params{}
 
 	response := service.Driver.Post(
@@ -73,22 +81,30 @@ func (service *FeatureService) Disable(account *Account, feature *Feature) error
 }
 
 func (service *FeatureService) collection(path string, params Params) []*Feature {
+	features, _ := service.fetch(path, params)
+
+	return features
+}
+
+func (service *FeatureService) fetch(path string, params Params) ([]*Feature, error) {
 	features := make([]*Feature, 0)
 	response := service.Driver.Get(path, params)
 
-	if response.Okay() {
-		for _, page := range response.Pages {
-			wrapper := struct {
-				Features []*Feature `json:"features,omitempty"`
-			}{}
+	if !response.Okay() {
+		return features, response.Error
+	}
 
-			if err := json.Unmarshal(page, &wrapper); err == nil {
-				features = append(features, wrapper.Features...)
-			}
+	for _, page := range response.Pages {
+		wrapper := struct {
+			Features []*Feature `json:"features,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(page, &wrapper); err == nil {
+			features = append(features, wrapper.Features...)
 		}
 	}
 
-	return features
+	return features, nil
 }
 
 /*
@@ -0,0 +1,229 @@
+package eygo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// EnableFeature is the FeatureChange action that turns a feature on.
+	EnableFeature = "enable"
+
+	// DisableFeature is the FeatureChange action that turns a feature off.
+	DisableFeature = "disable"
+)
+
+// FeatureChange is a data structure that describes what a FeaturePlan
+// intends to do with a single Feature and, once applied, how that went.
+type FeatureChange struct {
+	Feature *Feature
+	Action  string
+	Applied bool
+	Error   error
+}
+
+// FeaturePlan is a data structure that describes the changes needed to bring
+// an Account's enabled features in line with a desired set.
+type FeaturePlan struct {
+	Account *Account
+	Changes []*FeatureChange
+
+	// Unknown lists the desired features that don't match any feature on the
+	// API. A plan with unknown features can't be applied.
+	Unknown []string
+}
+
+// Empty returns true if the plan doesn't change anything, and false
+// otherwise.
+func (plan *FeaturePlan) Empty() bool {
+	return len(plan.Changes) == 0
+}
+
+// Enable returns the features that the plan turns on.
+func (plan *FeaturePlan) Enable() []*Feature {
+	return plan.features(EnableFeature)
+}
+
+// Disable returns the features that the plan turns off.
+func (plan *FeaturePlan) Disable() []*Feature {
+	return plan.features(DisableFeature)
+}
+
+// Failed returns the changes that could not be applied.
+func (plan *FeaturePlan) Failed() []*FeatureChange {
+	failed := make([]*FeatureChange, 0)
+
+	for _, change := range plan.Changes {
+		if change.Error != nil {
+			failed = append(failed, change)
+		}
+	}
+
+	return failed
+}
+
+func (plan *FeaturePlan) features(action string) []*Feature {
+	features := make([]*Feature, 0)
+
+	for _, change := range plan.Changes {
+		if change.Action == action {
+			features = append(features, change.Feature)
+		}
+	}
+
+	return features
+}
+
+// PlanFeatures takes the features available on the API, the features that
+// are currently enabled, and the IDs or names of the features that should be
+// enabled. It returns a FeaturePlan that enables the missing features and
+// disables the unwanted ones. Names are matched without regard to case.
+func PlanFeatures(available []*Feature, enabled []*Feature, desired []string) *FeaturePlan {
+	plan := &FeaturePlan{
+		Changes: make([]*FeatureChange, 0),
+		Unknown: make([]string, 0),
+	}
+
+	wanted := make(map[string]*Feature)
+
+	for _, identifier := range desired {
+		feature := findFeature(available, identifier)
+		if feature == nil {
+			feature = findFeature(enabled, identifier)
+		}
+
+		if feature == nil {
+			plan.Unknown = append(plan.Unknown, identifier)
+			continue
+		}
+
+		wanted[feature.ID] = feature
+	}
+
+	current := make(map[string]bool)
+
+	for _, feature := range enabled {
+		current[feature.ID] = true
+
+		if wanted[feature.ID] == nil {
+			plan.Changes = append(plan.Changes, &FeatureChange{Feature: feature, Action: DisableFeature})
+		}
+	}
+
+	for id, feature := range wanted {
+		if !current[id] {
+			plan.Changes = append(plan.Changes, &FeatureChange{Feature: feature, Action: EnableFeature})
+		}
+	}
+
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		left, right := plan.Changes[i], plan.Changes[j]
+
+		if left.Action != right.Action {
+			return left.Action > right.Action
+		}
+
+		return left.Feature.ID < right.Feature.ID
+	})
+
+	return plan
+}
+
+func findFeature(features []*Feature, identifier string) *Feature {
+	for _, feature := range features {
+		if feature.ID == identifier {
+			return feature
+		}
+	}
+
+	for _, feature := range features {
+		if strings.EqualFold(feature.Name, identifier) {
+			return feature
+		}
+	}
+
+	return nil
+}
+
+// PlanSync plans the synchronization of the given Account's enabled features
+// with the given IDs or names of the features that it should have. If any of
+// the desired features are unknown, an error is returned along with the plan.
+// If the features can't be retrieved, no plan is made and an error is
+// returned.
+func (service *FeatureService) PlanSync(account *Account, desired []string) (*FeaturePlan, error) {
+	if account == nil || len(account.ID) == 0 {
+		return nil, fmt.Errorf("No valid account given")
+	}
+
+	available, err := service.fetch("features", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, err := service.fetch("accounts/"+account.ID+"/features", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := PlanFeatures(available, enabled, desired)
+	plan.Account = account
+
+	if len(plan.Unknown) > 0 {
+		return plan, fmt.Errorf("Unknown features: %s", strings.Join(plan.Unknown, ", "))
+	}
+
+	return plan, nil
+}
+
+// Sync plans the synchronization of the given Account's enabled features
+// with the given IDs or names of the features that it should have. Unless
+// dryRun is true, each change in the plan is then applied through the API,
+// and the outcome is recorded in the change. If any change fails, an error is
+// returned along with the plan.
+func (service *FeatureService) Sync(account *Account, desired []string, dryRun bool) (*FeaturePlan, error) {
+	plan, err := service.PlanSync(account, desired)
+	if err != nil || dryRun {
+		return plan, err
+	}
+
+	failures := 0
+
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case EnableFeature:
+			change.Error = service.Enable(account, change.Feature)
+		case DisableFeature:
+			change.Error = service.Disable(account, change.Feature)
+		}
+
+		if change.Error != nil {
+			failures = failures + 1
+			continue
+		}
+
+		change.Applied = true
+	}
+
+	if failures > 0 {
+		return plan, fmt.Errorf("Couldn't apply %d feature changes", failures)
+	}
+
+	return plan, nil
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
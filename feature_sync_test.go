@@ -0,0 +1,179 @@
+package eygo
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPlanFeatures(t *testing.T) {
+	logs := &Feature{ID: "f1", Name: "Logs"}
+	metrics := &Feature{ID: "f2", Name: "Metrics"}
+	backups := &Feature{ID: "f3", Name: "Backups"}
+	legacy := &Feature{ID: "f4", Name: "Legacy"}
+
+	available := []*Feature{logs, metrics, backups}
+	enabled := []*Feature{logs, legacy}
+
+	plan := PlanFeatures(available, enabled, []string{"f1", "metrics", "BACKUPS", "Teleport"})
+
+	t.Run("it enables the missing features", func(t *testing.T) {
+		enable := plan.Enable()
+
+		if len(enable) != 2 || enable[0] != metrics || enable[1] != backups {
+			t.Errorf("Unexpected features to enable: %v", enable)
+		}
+	})
+
+	t.Run("it disables the unwanted features", func(t *testing.T) {
+		disable := plan.Disable()
+
+		if len(disable) != 1 || disable[0] != legacy {
+			t.Errorf("Unexpected features to disable: %v", disable)
+		}
+	})
+
+	t.Run("it reports unknown features", func(t *testing.T) {
+		if len(plan.Unknown) != 1 || plan.Unknown[0] != "Teleport" {
+			t.Errorf("Unexpected unknown features: %v", plan.Unknown)
+		}
+	})
+
+	t.Run("it is empty when the features already match", func(t *testing.T) {
+		if !PlanFeatures(available, enabled, []string{"Logs", "f4"}).Empty() {
+			t.Errorf("Expected an empty plan")
+		}
+	})
+}
+
+func TestFeatureService_Sync(t *testing.T) {
+	account := &Account{ID: "1"}
+	logs := &Feature{ID: "f1", Name: "Logs"}
+	metrics := &Feature{ID: "f2", Name: "Metrics"}
+	legacy := &Feature{ID: "f4", Name: "Legacy"}
+	driver := NewMockDriver()
+	service := NewFeatureService(driver)
+
+	t.Run("without an account", func(t *testing.T) {
+		if _, err := service.Sync(nil, []string{"Logs"}, false); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with unknown features", func(t *testing.T) {
+		driver.Reset()
+		stubFeatures(driver, logs, metrics)
+		stubAccountFeatures(driver, account, legacy)
+
+		plan, err := service.Sync(account, []string{"Logs", "Teleport"}, false)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it returns the plan", func(t *testing.T) {
+			if plan == nil || len(plan.Unknown) != 1 {
+				t.Errorf("Expected a plan with an unknown feature, got %v", plan)
+			}
+		})
+
+		t.Run("it doesn't change anything", func(t *testing.T) {
+			if len(driver.Requests("post")) != 0 || len(driver.Requests("delete")) != 0 {
+				t.Errorf("Expected no changes")
+			}
+		})
+	})
+
+	failures := map[string]string{
+		"available features": "features",
+		"enabled features":   "accounts/1/features",
+	}
+
+	for name, path := range failures {
+		t.Run("when the "+name+" can't be retrieved", func(t *testing.T) {
+			driver.Reset()
+			stubFeatures(driver, logs, metrics)
+			stubAccountFeatures(driver, account, legacy, logs, metrics)
+			driver.RemoveResponse("get", path)
+			driver.AddResponse("get", path, Response{Error: fmt.Errorf("Oh no!")})
+
+			plan, err := service.Sync(account, []string{"Logs"}, false)
+
+			t.Run("it returns an error", func(t *testing.T) {
+				if err == nil {
+					t.Errorf("Expected an error")
+				}
+			})
+
+			t.Run("it returns no plan", func(t *testing.T) {
+				if plan != nil {
+					t.Errorf("Expected no plan, got %v", plan)
+				}
+			})
+
+			t.Run("it doesn't change anything", func(t *testing.T) {
+				if len(driver.Requests("post")) != 0 || len(driver.Requests("delete")) != 0 {
+					t.Errorf("Expected no changes")
+				}
+			})
+		})
+	}
+
+	t.Run("as a dry run", func(t *testing.T) {
+		driver.Reset()
+		stubFeatures(driver, logs, metrics)
+		stubAccountFeatures(driver, account, legacy)
+
+		plan, err := service.Sync(account, []string{"Logs", "Metrics"}, true)
+
+		t.Run("it returns the plan", func(t *testing.T) {
+			if err != nil || len(plan.Changes) != 3 {
+				t.Errorf("Expected a plan with 3 changes, got %v (%v)", plan, err)
+			}
+		})
+
+		t.Run("it doesn't change anything", func(t *testing.T) {
+			if len(driver.Requests("post")) != 0 || len(driver.Requests("delete")) != 0 {
+				t.Errorf("Expected no changes")
+			}
+		})
+	})
+
+	t.Run("when some changes fail", func(t *testing.T) {
+		driver.Reset()
+		stubFeatures(driver, logs, metrics)
+		stubAccountFeatures(driver, account, legacy)
+		driver.AddResponse("post", "accounts/1/features/f1", Response{Pages: [][]byte{[]byte(`true`)}})
+		driver.AddResponse("post", "accounts/1/features/f2", Response{Error: fmt.Errorf("Oh no!")})
+		driver.AddResponse("delete", "accounts/1/features/f4", Response{Pages: [][]byte{[]byte(`true`)}})
+
+		plan, err := service.Sync(account, []string{"Logs", "Metrics"}, false)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it applies every change", func(t *testing.T) {
+			if len(driver.Requests("post")) != 2 || len(driver.Requests("delete")) != 1 {
+				t.Errorf("Expected every change to be attempted")
+			}
+		})
+
+		t.Run("it reports errors per feature", func(t *testing.T) {
+			failed := plan.Failed()
+
+			if len(failed) != 1 || failed[0].Feature.ID != metrics.ID {
+				t.Errorf("Expected only Metrics to fail, got %v", failed[0].Feature)
+			}
+
+			for _, change := range plan.Changes {
+				if change.Applied == (change.Error != nil) {
+					t.Errorf("Unexpected outcome for %s: %v", change.Feature.Name, change)
+				}
+			}
+		})
+	})
+}
@@ -128,6 +128,26 @@ func TestFeatureService_Enable(t *testing.T) {
 	account := &Account{ID: "1", Name: "Account 1"}
 	feature := &Feature{ID: "Feature1"}
 
+	t.Run("with an invalid account", func(t *testing.T) {
+		for _, invalid := range []*Account{nil, {}} {
+			if err := service.Enable(invalid, feature); err == nil {
+				t.Errorf("Expected an error")
+			}
+		}
+	})
+
+	t.Run("with an invalid feature", func(t *testing.T) {
+		for _, invalid := range []*Feature{nil, {}} {
+			if err := service.Enable(account, invalid); err == nil {
+				t.Errorf("Expected an error")
+			}
+		}
+
+		if len(driver.Requests("post")) != 0 {
+			t.Errorf("Expected no post requests")
+		}
+	})
+
 	t.Run("when successful", func(t *testing.T) {
 		driver.Reset()
 
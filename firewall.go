@@ -0,0 +1,308 @@
+package eygo
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// TCPProtocol is the protocol for rules that allow TCP traffic.
+	TCPProtocol = "tcp"
+
+	// UDPProtocol is the protocol for rules that allow UDP traffic.
+	UDPProtocol = "udp"
+
+	// ICMPProtocol is the protocol for rules that allow ICMP traffic. ICMP
+	// rules don't have ports.
+	ICMPProtocol = "icmp"
+)
+
+// FirewallRule is a data structure that models a single inbound rule of an
+// Environment's firewall on the Engine Yard API.
+type FirewallRule struct {
+	Protocol    string `json:"protocol,omitempty"`
+	FromPort    int    `json:"from_port,omitempty"`
+	ToPort      int    `json:"to_port,omitempty"`
+	SourceCIDR  string `json:"source,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Validate returns an error if the rule doesn't have a known protocol, a
+// valid port range for that protocol, and a valid source CIDR. Otherwise, it
+// returns nil.
+func (rule *FirewallRule) Validate() error {
+	switch rule.Protocol {
+	case TCPProtocol, UDPProtocol:
+		if rule.FromPort < 1 || rule.FromPort > 65535 {
+			return fmt.Errorf("Invalid port %d", rule.FromPort)
+		}
+
+		if rule.ToPort < 1 || rule.ToPort > 65535 {
+			return fmt.Errorf("Invalid port %d", rule.ToPort)
+		}
+
+		if rule.FromPort > rule.ToPort {
+			return fmt.Errorf("Port range %d-%d is backwards", rule.FromPort, rule.ToPort)
+		}
+	case ICMPProtocol:
+		if rule.FromPort != 0 || rule.ToPort != 0 {
+			return fmt.Errorf("ICMP rules can't have ports")
+		}
+	default:
+		return fmt.Errorf("Unknown protocol %q", rule.Protocol)
+	}
+
+	if _, err := ParseCIDR(rule.SourceCIDR); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String returns a readable representation of the rule, such as
+// "tcp 80-443 from 0.0.0.0/0".
+func (rule *FirewallRule) String() string {
+	if rule.Protocol == ICMPProtocol {
+		return fmt.Sprintf("%s from %s", rule.Protocol, rule.SourceCIDR)
+	}
+
+	return fmt.Sprintf("%s %d-%d from %s", rule.Protocol, rule.FromPort, rule.ToPort, rule.SourceCIDR)
+}
+
+// key returns a string that identifies the traffic the rule allows, ignoring
+// its description and the formatting of its CIDR.
+func (rule *FirewallRule) key() string {
+	source := rule.SourceCIDR
+	if block, err := ParseCIDR(source); err == nil {
+		source = block.String()
+	}
+
+	return fmt.Sprintf("%s %d-%d from %s", strings.ToLower(rule.Protocol), rule.FromPort, rule.ToPort, source)
+}
+
+// Firewall is a data structure that models the firewall of an Environment on
+// the Engine Yard API.
+type Firewall struct {
+	ID             int             `json:"id,omitempty"`
+	EnvironmentURL string          `json:"environment,omitempty"`
+	Rules          []*FirewallRule `json:"rules,omitempty"`
+	CreatedAt      string          `json:"created_at,omitempty"`
+	UpdatedAt      string          `json:"updated_at,omitempty"`
+}
+
+// FirewallPlan is a data structure that describes the changes needed to
+// replace the rules of a Firewall with a desired set of rules.
+type FirewallPlan struct {
+	Firewall *Firewall
+	Add      []*FirewallRule
+	Remove   []*FirewallRule
+
+	// Update lists the desired rules that allow the same traffic as a current
+	// rule but have a different description.
+	Update []*FirewallRule
+
+	Keep    []*FirewallRule
+	Applied bool
+}
+
+// Empty returns true if the plan doesn't change any rules, and false
+// otherwise.
+func (plan *FirewallPlan) Empty() bool {
+	return len(plan.Add) == 0 && len(plan.Remove) == 0 && len(plan.Update) == 0
+}
+
+// Rules returns the rules that the firewall has once the plan is applied.
+func (plan *FirewallPlan) Rules() []*FirewallRule {
+	rules := make([]*FirewallRule, 0, len(plan.Keep)+len(plan.Update)+len(plan.Add))
+	rules = append(rules, plan.Keep...)
+	rules = append(rules, plan.Update...)
+
+	return append(rules, plan.Add...)
+}
+
+// PlanFirewall compares the current rules of a firewall with the desired
+// rules, returning a FirewallPlan that describes which rules are added,
+// removed, updated, and kept. Rules are compared by protocol, ports, and
+// source, so a desired rule that differs from a current rule only in its
+// description updates that rule. Empty entries in the current rules are
+// ignored. If any desired rule is invalid, an error is returned.
+func PlanFirewall(current []*FirewallRule, desired []*FirewallRule) (*FirewallPlan, error) {
+	plan := &FirewallPlan{
+		Add:    make([]*FirewallRule, 0),
+		Remove: make([]*FirewallRule, 0),
+		Update: make([]*FirewallRule, 0),
+		Keep:   make([]*FirewallRule, 0),
+	}
+
+	wanted := make(map[string]*FirewallRule)
+
+	for _, rule := range desired {
+		if rule == nil {
+			return nil, fmt.Errorf("No valid rule given")
+		}
+
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("Rule %s: %s", rule, err)
+		}
+
+		wanted[rule.key()] = rule
+	}
+
+	existing := make(map[string]bool)
+
+	for _, rule := range current {
+		if rule == nil {
+			continue
+		}
+
+		key := rule.key()
+		match := wanted[key]
+
+		switch {
+		case existing[key] || match == nil:
+			plan.Remove = append(plan.Remove, rule)
+		case match.Description != rule.Description:
+			plan.Update = append(plan.Update, match)
+		default:
+			plan.Keep = append(plan.Keep, rule)
+		}
+
+		existing[key] = true
+	}
+
+	for key, rule := range wanted {
+		if !existing[key] {
+			plan.Add = append(plan.Add, rule)
+		}
+	}
+
+	for _, rules := range [][]*FirewallRule{plan.Add, plan.Remove, plan.Update, plan.Keep} {
+		sort.SliceStable(rules, func(i, j int) bool { return rules[i].key() < rules[j].key() })
+	}
+
+	return plan, nil
+}
+
+// FirewallService is a repository one can use to read and replace the
+// firewall rules of an Environment on the API.
+type FirewallService struct {
+	Driver Driver
+}
+
+// NewFirewallService returns a FirewallService configured to use the provided
+// Driver.
+func NewFirewallService(driver Driver) *FirewallService {
+	return &FirewallService{Driver: driver}
+}
+
+// ForEnvironment returns the Firewall of the given Environment. If there are
+// issues along the way, an error is returned.
+func (service *FirewallService) ForEnvironment(environment *Environment) (*Firewall, error) {
+	if environment == nil || environment.ID == 0 {
+		return nil, fmt.Errorf("No valid environment given")
+	}
+
+	return service.unwrap(service.Driver.Get(service.firewallPath(environment), nil))
+}
+
+// Plan retrieves the Firewall of the given Environment and returns the
+// FirewallPlan that would replace its rules with the given rules. Nothing is
+// changed by this method.
+func (service *FirewallService) Plan(environment *Environment, rules []*FirewallRule) (*FirewallPlan, error) {
+	firewall, err := service.ForEnvironment(environment)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := PlanFirewall(firewall.Rules, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Firewall = firewall
+
+	return plan, nil
+}
+
+// Replace plans the replacement of the given Environment's firewall rules
+// with the given rules. Unless dryRun is true or the plan is empty, the
+// resulting rules are then saved through the API, and the plan's Firewall is
+// updated with the result. If there are issues along the way, an error is
+// returned along with the plan.
+func (service *FirewallService) Replace(environment *Environment, rules []*FirewallRule, dryRun bool) (*FirewallPlan, error) {
+	plan, err := service.Plan(environment, rules)
+	if err != nil || dryRun || plan.Empty() {
+		return plan, err
+	}
+
+	wrapper := struct {
+		Firewall struct {
+			Rules []*FirewallRule `json:"rules"`
+		} `json:"firewall"`
+	}{}
+	wrapper.Firewall.Rules = plan.Rules()
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return plan, err
+	}
+
+	firewall, err := service.unwrap(
+		service.Driver.Put(service.firewallPath(environment), nil, body),
+	)
+	if err != nil {
+		return plan, err
+	}
+
+	plan.Firewall = firewall
+	plan.Applied = true
+
+	return plan, nil
+}
+
+func (service *FirewallService) firewallPath(environment *Environment) string {
+	if len(environment.FirewallURL) > 0 {
+		return pathFor(environment.FirewallURL)
+	}
+
+	return fmt.Sprintf("environments/%d/firewall", environment.ID)
+}
+
+func (service *FirewallService) unwrap(response Response) (*Firewall, error) {
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		Firewall *Firewall `json:"firewall,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	if wrapper.Firewall == nil {
+		return nil, fmt.Errorf("No firewall returned")
+	}
+
+	return wrapper.Firewall, nil
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
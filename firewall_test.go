@@ -0,0 +1,230 @@
+package eygo
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestFirewallRule_Validate(t *testing.T) {
+	t.Run("it accepts valid rules", func(t *testing.T) {
+		valid := []*FirewallRule{
+			{Protocol: TCPProtocol, FromPort: 22, ToPort: 22, SourceCIDR: "10.0.0.0/8"},
+			{Protocol: UDPProtocol, FromPort: 1, ToPort: 65535, SourceCIDR: "0.0.0.0/0"},
+			{Protocol: ICMPProtocol, SourceCIDR: "2001:db8::/32"},
+		}
+
+		for _, rule := range valid {
+			if err := rule.Validate(); err != nil {
+				t.Errorf("Expected %s to be valid, got %s", rule, err)
+			}
+		}
+	})
+
+	t.Run("it rejects invalid rules", func(t *testing.T) {
+		invalid := []*FirewallRule{
+			{Protocol: "sctp", FromPort: 22, ToPort: 22, SourceCIDR: "10.0.0.0/8"},
+			{Protocol: TCPProtocol, FromPort: 0, ToPort: 22, SourceCIDR: "10.0.0.0/8"},
+			{Protocol: TCPProtocol, FromPort: 22, ToPort: 65536, SourceCIDR: "10.0.0.0/8"},
+			{Protocol: TCPProtocol, FromPort: 443, ToPort: 80, SourceCIDR: "10.0.0.0/8"},
+			{Protocol: ICMPProtocol, FromPort: 8, SourceCIDR: "10.0.0.0/8"},
+			{Protocol: TCPProtocol, FromPort: 22, ToPort: 22, SourceCIDR: "10.0.0.1/8"},
+			{Protocol: TCPProtocol, FromPort: 22, ToPort: 22, SourceCIDR: "nope"},
+		}
+
+		for _, rule := range invalid {
+			if err := rule.Validate(); err == nil {
+				t.Errorf("Expected %s to be invalid", rule)
+			}
+		}
+	})
+}
+
+func TestPlanFirewall(t *testing.T) {
+	ssh := &FirewallRule{Protocol: TCPProtocol, FromPort: 22, ToPort: 22, SourceCIDR: "10.0.0.0/8"}
+	web := &FirewallRule{Protocol: TCPProtocol, FromPort: 80, ToPort: 443, SourceCIDR: "0.0.0.0/0"}
+	ping := &FirewallRule{Protocol: ICMPProtocol, SourceCIDR: "0.0.0.0/0"}
+
+	t.Run("with changes", func(t *testing.T) {
+		same := &FirewallRule{Protocol: ICMPProtocol, SourceCIDR: "10.0.0.0/8"}
+
+		plan, err := PlanFirewall([]*FirewallRule{ssh, ping, same}, []*FirewallRule{ssh, web, same})
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		t.Run("it keeps matching rules", func(t *testing.T) {
+			if len(plan.Keep) != 2 {
+				t.Errorf("Expected 2 rules to be kept, got %v", plan.Keep)
+			}
+		})
+
+		t.Run("it adds missing rules", func(t *testing.T) {
+			if len(plan.Add) != 1 || plan.Add[0] != web {
+				t.Errorf("Expected web to be added, got %v", plan.Add)
+			}
+		})
+
+		t.Run("it removes undesired rules", func(t *testing.T) {
+			if len(plan.Remove) != 1 || plan.Remove[0] != ping {
+				t.Errorf("Expected ping to be removed, got %v", plan.Remove)
+			}
+		})
+
+		t.Run("it knows the resulting rules", func(t *testing.T) {
+			if len(plan.Rules()) != 3 || plan.Empty() {
+				t.Errorf("Expected 2 resulting rules, got %v", plan.Rules())
+			}
+		})
+	})
+
+	t.Run("when only a description changes", func(t *testing.T) {
+		described := &FirewallRule{Protocol: TCPProtocol, FromPort: 22, ToPort: 22, SourceCIDR: "10.0.0.0/8", Description: "ssh"}
+
+		plan, _ := PlanFirewall([]*FirewallRule{ssh}, []*FirewallRule{described})
+
+		t.Run("it updates the rule", func(t *testing.T) {
+			if plan.Empty() || len(plan.Update) != 1 || plan.Update[0] != described {
+				t.Errorf("Expected the described rule to be an update, got %v", plan.Update)
+			}
+
+			if len(plan.Rules()) != 1 || plan.Rules()[0].Description != "ssh" {
+				t.Errorf("Expected the resulting rule to carry the description")
+			}
+		})
+	})
+
+	t.Run("it ignores empty current rules", func(t *testing.T) {
+		plan, err := PlanFirewall([]*FirewallRule{nil, ssh}, []*FirewallRule{ssh})
+
+		if err != nil || !plan.Empty() {
+			t.Errorf("Expected an empty plan, got %v (%v)", plan, err)
+		}
+	})
+
+	t.Run("it removes duplicate current rules", func(t *testing.T) {
+		duplicate := &FirewallRule{Protocol: TCPProtocol, FromPort: 22, ToPort: 22, SourceCIDR: "10.0.0.0/8"}
+
+		plan, _ := PlanFirewall([]*FirewallRule{ssh, duplicate}, []*FirewallRule{ssh})
+
+		if len(plan.Keep) != 1 || len(plan.Remove) != 1 || plan.Remove[0] != duplicate {
+			t.Errorf("Expected the duplicate to be removed")
+		}
+	})
+
+	t.Run("it is empty when nothing changes", func(t *testing.T) {
+		plan, _ := PlanFirewall([]*FirewallRule{ssh, web}, []*FirewallRule{web, ssh})
+
+		if !plan.Empty() {
+			t.Errorf("Expected an empty plan")
+		}
+	})
+
+	t.Run("it rejects invalid desired rules", func(t *testing.T) {
+		bad := &FirewallRule{Protocol: TCPProtocol, SourceCIDR: "10.0.0.0/8"}
+
+		if _, err := PlanFirewall(nil, []*FirewallRule{bad}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestFirewallService_ForEnvironment(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewFirewallService(driver)
+
+	t.Run("with an invalid environment", func(t *testing.T) {
+		if _, err := service.ForEnvironment(&Environment{}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("it follows the environment's firewall URL", func(t *testing.T) {
+		environment := &Environment{ID: 1, FirewallURL: "https://api.engineyard.com/firewalls/9"}
+		stubFirewall(driver, "get", "firewalls/9", &Firewall{ID: 9})
+
+		firewall, err := service.ForEnvironment(environment)
+
+		if err != nil || firewall.ID != 9 {
+			t.Errorf("Expected firewall 9, got %v (%v)", firewall, err)
+		}
+	})
+}
+
+func TestFirewallService_Replace(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewFirewallService(driver)
+	environment := &Environment{ID: 1}
+	ssh := &FirewallRule{Protocol: TCPProtocol, FromPort: 22, ToPort: 22, SourceCIDR: "10.0.0.0/8"}
+	web := &FirewallRule{Protocol: TCPProtocol, FromPort: 80, ToPort: 443, SourceCIDR: "0.0.0.0/0"}
+
+	t.Run("as a dry run", func(t *testing.T) {
+		stubFirewall(driver, "get", "environments/1/firewall", &Firewall{ID: 1, Rules: []*FirewallRule{ssh}})
+
+		plan, err := service.Replace(environment, []*FirewallRule{ssh, web}, true)
+
+		t.Run("it plans without saving", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if plan.Applied || len(plan.Add) != 1 || len(driver.Requests("put")) != 0 {
+				t.Errorf("Expected an unapplied plan")
+			}
+		})
+	})
+
+	t.Run("when nothing changes", func(t *testing.T) {
+		driver.Reset()
+		stubFirewall(driver, "get", "environments/1/firewall", &Firewall{ID: 1, Rules: []*FirewallRule{ssh}})
+
+		if plan, _ := service.Replace(environment, []*FirewallRule{ssh}, false); plan.Applied || len(driver.Requests("put")) != 0 {
+			t.Errorf("Expected nothing to be saved")
+		}
+	})
+
+	t.Run("when successful", func(t *testing.T) {
+		driver.Reset()
+		stubFirewall(driver, "get", "environments/1/firewall", &Firewall{ID: 1, Rules: []*FirewallRule{ssh}})
+		stubFirewall(driver, "put", "environments/1/firewall", &Firewall{ID: 1, Rules: []*FirewallRule{web}})
+
+		plan, err := service.Replace(environment, []*FirewallRule{web}, false)
+
+		t.Run("it saves the resulting rules", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if !plan.Applied || len(plan.Firewall.Rules) != 1 {
+				t.Errorf("Expected an applied plan")
+			}
+
+			expected := `{"firewall":{"rules":[{"protocol":"tcp","from_port":80,"to_port":443,"source":"0.0.0.0/0"}]}}`
+			if string(driver.Bodies("put")[0]) != expected {
+				t.Errorf("Expected body %s, got %s", expected, driver.Bodies("put")[0])
+			}
+		})
+	})
+
+	t.Run("when unsuccessful", func(t *testing.T) {
+		driver.Reset()
+		stubFirewall(driver, "get", "environments/1/firewall", &Firewall{ID: 1})
+		driver.AddResponse("put", "environments/1/firewall", Response{Error: fmt.Errorf("Oh no!")})
+
+		plan, err := service.Replace(environment, []*FirewallRule{web}, false)
+
+		if err == nil || plan == nil || plan.Applied {
+			t.Errorf("Expected an error along with the unapplied plan")
+		}
+	})
+}
+
+func stubFirewall(driver *MockDriver, method string, path string, firewall *Firewall) {
+	wrapper := struct {
+		Firewall *Firewall `json:"firewall"`
+	}{Firewall: firewall}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		driver.AddResponse(method, path, Response{Pages: [][]byte{encoded}})
+	}
+}
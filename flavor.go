@@ -34,6 +34,12 @@ func (service *FlavorService) ForAccount(account *Account, params Params) []*Fla
 	return service.collection("accounts/"+account.ID+"/flavors", params)
 }
 
+// Catalog returns a FlavorCatalog of the flavors offered to the given
+// Account.
+func (service *FlavorService) Catalog(account *Account) *FlavorCatalog {
+	return NewFlavorCatalog(service.ForAccount(account, nil))
+}
+
 func (service *FlavorService) collection(path string, params Params) []*Flavor {
 	flavors := make([]*Flavor, 0)
 	response := service.Driver.Get(path, params)
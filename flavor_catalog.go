@@ -0,0 +1,225 @@
+package eygo
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FlavorName is a data structure that describes the parts of a flavor's API
+// name, such as "m5.2xlarge".
+type FlavorName struct {
+	// Family is the part of the name before the dot, such as "m5".
+	Family string
+
+	// Series is the letters of the family, such as "m".
+	Series string
+
+	// Generation is the number of the family, such as 5.
+	Generation int
+
+	// Size is the part of the name after the dot, such as "2xlarge".
+	Size string
+
+	// SizeRank orders sizes from smallest to largest.
+	SizeRank int
+}
+
+var (
+	flavorFamilyPattern = regexp.MustCompile(`^([a-z]+)(\d+)([a-z]*)$`)
+	flavorSizePattern   = regexp.MustCompile(`^(\d*)xlarge$`)
+	flavorSizeRanks     = map[string]int{
+		"nano":   0,
+		"micro":  1,
+		"small":  2,
+		"medium": 3,
+		"large":  4,
+		"metal":  1000,
+	}
+)
+
+// ParseFlavorName takes a flavor API name such as "c5.xlarge" and returns the
+// FlavorName that describes it. If the name isn't in the family.size form, an
+// error is returned.
+func ParseFlavorName(apiName string) (FlavorName, error) {
+	name := FlavorName{}
+	parts := strings.SplitN(strings.ToLower(apiName), ".", 2)
+
+	if len(parts) != 2 {
+		return name, fmt.Errorf("Flavor name %q is not in the family.size form", apiName)
+	}
+
+	family := flavorFamilyPattern.FindStringSubmatch(parts[0])
+	if family == nil {
+		return name, fmt.Errorf("Flavor name %q has an unknown family", apiName)
+	}
+
+	name.Family = parts[0]
+	name.Series = family[1]
+	name.Generation, _ = strconv.Atoi(family[2])
+	name.Size = parts[1]
+
+	if rank, known := flavorSizeRanks[name.Size]; known {
+		name.SizeRank = rank
+		return name, nil
+	}
+
+	size := flavorSizePattern.FindStringSubmatch(name.Size)
+	if size == nil {
+		return name, fmt.Errorf("Flavor name %q has an unknown size", apiName)
+	}
+
+	multiple := 1
+	if len(size[1]) > 0 {
+		multiple, _ = strconv.Atoi(size[1])
+	}
+
+	name.SizeRank = flavorSizeRanks["large"] + multiple
+
+	return name, nil
+}
+
+// FlavorFilter is a data structure that describes the flavors to select from
+// a FlavorCatalog. Zero values match every flavor.
+type FlavorFilter struct {
+	Architecture    int
+	Dedicated       *bool
+	VolumeOptimized *bool
+	Family          string
+}
+
+// Match returns true if the given Flavor satisfies the filter, and false
+// otherwise.
+func (filter FlavorFilter) Match(flavor *Flavor) bool {
+	if filter.Architecture != 0 && flavor.Architecture != filter.Architecture {
+		return false
+	}
+
+	if filter.Dedicated != nil && flavor.Dedicated != *filter.Dedicated {
+		return false
+	}
+
+	if filter.VolumeOptimized != nil && flavor.VolumeOptimized != *filter.VolumeOptimized {
+		return false
+	}
+
+	if len(filter.Family) > 0 {
+		name, err := ParseFlavorName(flavor.APIName)
+		if err != nil || !strings.EqualFold(name.Family, filter.Family) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FlavorCatalog is a data structure that holds the flavors offered to an
+// account, ordered from smallest to largest.
+type FlavorCatalog struct {
+	Flavors []*Flavor
+}
+
+// NewFlavorCatalog returns a FlavorCatalog of the given flavors. Flavors are
+// ordered by size, then by family, and flavors whose API names can't be
+// parsed come last.
+func NewFlavorCatalog(flavors []*Flavor) *FlavorCatalog {
+	ordered := make([]*Flavor, len(flavors))
+	copy(ordered, flavors)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		left, leftErr := ParseFlavorName(ordered[i].APIName)
+		right, rightErr := ParseFlavorName(ordered[j].APIName)
+
+		switch {
+		case leftErr != nil || rightErr != nil:
+			if (leftErr == nil) != (rightErr == nil) {
+				return leftErr == nil
+			}
+
+			return ordered[i].APIName < ordered[j].APIName
+		case left.SizeRank != right.SizeRank:
+			return left.SizeRank < right.SizeRank
+		case left.Series != right.Series:
+			return left.Series < right.Series
+		case left.Generation != right.Generation:
+			return left.Generation > right.Generation
+		default:
+			return left.Family < right.Family
+		}
+	})
+
+	return &FlavorCatalog{Flavors: ordered}
+}
+
+// Find returns the Flavor in the catalog with the given ID or API name. If
+// there is no such flavor, an error is returned.
+func (catalog *FlavorCatalog) Find(identifier string) (*Flavor, error) {
+	for _, flavor := range catalog.Flavors {
+		if flavor.ID == identifier || strings.EqualFold(flavor.APIName, identifier) {
+			return flavor, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Flavor %s is not offered", identifier)
+}
+
+// Filter returns the flavors in the catalog that match the given
+// FlavorFilter, from smallest to largest.
+func (catalog *FlavorCatalog) Filter(filter FlavorFilter) []*Flavor {
+	flavors := make([]*Flavor, 0)
+
+	for _, flavor := range catalog.Flavors {
+		if filter.Match(flavor) {
+			flavors = append(flavors, flavor)
+		}
+	}
+
+	return flavors
+}
+
+// Smallest returns the smallest flavor in the catalog that matches the given
+// FlavorFilter. If no flavor matches, an error is returned.
+func (catalog *FlavorCatalog) Smallest(filter FlavorFilter) (*Flavor, error) {
+	flavors := catalog.Filter(filter)
+	if len(flavors) == 0 {
+		return nil, fmt.Errorf("No flavor matches the given filter")
+	}
+
+	return flavors[0], nil
+}
+
+// ValidateServer checks that the flavor requested by the given Server payload
+// is offered by the catalog. The flavor may be given by ID or API name.
+func (catalog *FlavorCatalog) ValidateServer(server *Server) error {
+	if server == nil {
+		return fmt.Errorf("No server given")
+	}
+
+	if len(server.Flavor.ID) == 0 {
+		return fmt.Errorf("Server %s has no flavor", server.Name)
+	}
+
+	if _, err := catalog.Find(server.Flavor.ID); err != nil {
+		return fmt.Errorf("Server %s: %s", server.Name, err)
+	}
+
+	return nil
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
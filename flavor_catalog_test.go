@@ -0,0 +1,143 @@
+package eygo
+
+import (
+	"testing"
+)
+
+func TestParseFlavorName(t *testing.T) {
+	cases := map[string]FlavorName{
+		"m3.medium":   {Family: "m3", Series: "m", Generation: 3, Size: "medium", SizeRank: 3},
+		"c5.xlarge":   {Family: "c5", Series: "c", Generation: 5, Size: "xlarge", SizeRank: 5},
+		"M5D.2XLARGE": {Family: "m5d", Series: "m", Generation: 5, Size: "2xlarge", SizeRank: 6},
+		"i3.metal":    {Family: "i3", Series: "i", Generation: 3, Size: "metal", SizeRank: 1000},
+	}
+
+	for apiName, expected := range cases {
+		t.Run("it parses "+apiName, func(t *testing.T) {
+			name, err := ParseFlavorName(apiName)
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if name != expected {
+				t.Errorf("Expected %+v, got %+v", expected, name)
+			}
+		})
+	}
+
+	for _, apiName := range []string{"", "large", "m.large", "m5.gigantic"} {
+		t.Run("it rejects "+apiName, func(t *testing.T) {
+			if _, err := ParseFlavorName(apiName); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func testFlavorCatalog() *FlavorCatalog {
+	return NewFlavorCatalog([]*Flavor{
+		{ID: "1", APIName: "m5.2xlarge", Architecture: 64, VolumeOptimized: true},
+		{ID: "2", APIName: "m3.large", Architecture: 64},
+		{ID: "3", APIName: "c5.large", Architecture: 64, VolumeOptimized: true, Dedicated: true},
+		{ID: "4", APIName: "m5.large", Architecture: 64, VolumeOptimized: true},
+		{ID: "5", APIName: "m1.small", Architecture: 32},
+		{ID: "6", APIName: "custom"},
+	})
+}
+
+func TestNewFlavorCatalog(t *testing.T) {
+	catalog := testFlavorCatalog()
+
+	t.Run("it orders flavors from smallest to largest", func(t *testing.T) {
+		expected := []string{"m1.small", "c5.large", "m5.large", "m3.large", "m5.2xlarge", "custom"}
+
+		for i, flavor := range catalog.Flavors {
+			if flavor.APIName != expected[i] {
+				t.Errorf("Expected %s at %d, got %s", expected[i], i, flavor.APIName)
+			}
+		}
+	})
+}
+
+func TestFlavorCatalog_Find(t *testing.T) {
+	catalog := testFlavorCatalog()
+
+	t.Run("it finds flavors by ID", func(t *testing.T) {
+		if flavor, err := catalog.Find("3"); err != nil || flavor.APIName != "c5.large" {
+			t.Errorf("Expected c5.large, got %v (%v)", flavor, err)
+		}
+	})
+
+	t.Run("it finds flavors by API name", func(t *testing.T) {
+		if flavor, err := catalog.Find("M3.Large"); err != nil || flavor.ID != "2" {
+			t.Errorf("Expected flavor 2, got %v (%v)", flavor, err)
+		}
+	})
+
+	t.Run("it reports unknown flavors", func(t *testing.T) {
+		if _, err := catalog.Find("x1.32xlarge"); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestFlavorCatalog_Filter(t *testing.T) {
+	catalog := testFlavorCatalog()
+	yes, no := true, false
+
+	t.Run("it selects by architecture", func(t *testing.T) {
+		if flavors := catalog.Filter(FlavorFilter{Architecture: 32}); len(flavors) != 1 || flavors[0].ID != "5" {
+			t.Errorf("Unexpected flavors: %v", flavors)
+		}
+	})
+
+	t.Run("it selects by family", func(t *testing.T) {
+		if flavors := catalog.Filter(FlavorFilter{Family: "m5"}); len(flavors) != 2 {
+			t.Errorf("Unexpected flavors: %v", flavors)
+		}
+	})
+
+	t.Run("it finds the smallest matching flavor", func(t *testing.T) {
+		flavor, err := catalog.Smallest(FlavorFilter{Architecture: 64, VolumeOptimized: &yes, Dedicated: &no})
+
+		if err != nil || flavor.APIName != "m5.large" {
+			t.Errorf("Expected m5.large, got %v (%v)", flavor, err)
+		}
+	})
+
+	t.Run("it reports when nothing matches", func(t *testing.T) {
+		if _, err := catalog.Smallest(FlavorFilter{Architecture: 32, Dedicated: &yes}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestFlavorCatalog_ValidateServer(t *testing.T) {
+	catalog := testFlavorCatalog()
+
+	t.Run("it accepts offered flavors", func(t *testing.T) {
+		for _, id := range []string{"4", "m5.large"} {
+			server := &Server{Name: "app"}
+			server.Flavor.ID = id
+
+			if err := catalog.ValidateServer(server); err != nil {
+				t.Errorf("Expected %s to be accepted, got %s", id, err)
+			}
+		}
+	})
+
+	t.Run("it rejects flavors that aren't offered", func(t *testing.T) {
+		server := &Server{Name: "app"}
+		server.Flavor.ID = "x1.32xlarge"
+
+		if err := catalog.ValidateServer(server); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("it rejects servers without a flavor", func(t *testing.T) {
+		if err := catalog.ValidateServer(&Server{Name: "app"}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
@@ -70,6 +70,27 @@ func TestFlavorService_ForAccount(t *testing.T) {
 
 }
 
+func TestFlavorService_Catalog(t *testing.T) {
+	account := &Account{ID: "1"}
+	driver := NewMockDriver()
+	service := NewFlavorService(driver)
+
+	stubAccountFlavors(
+		driver,
+		account,
+		&Flavor{ID: "1", APIName: "m5.xlarge"},
+		&Flavor{ID: "2", APIName: "m5.large"},
+	)
+
+	catalog := service.Catalog(account)
+
+	t.Run("it contains the account's flavors in order", func(t *testing.T) {
+		if len(catalog.Flavors) != 2 || catalog.Flavors[0].ID != "2" {
+			t.Errorf("Unexpected catalog: %v", catalog.Flavors)
+		}
+	})
+}
+
 func stubFlavors(driver *MockDriver, flavors ...*Flavor) {
 	pages := make([][]byte, 0)
 
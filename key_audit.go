@@ -0,0 +1,439 @@
+package eygo
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// UserKeyHolder is the Kind of a KeyHolder that is a User.
+	UserKeyHolder = "user"
+
+	// ApplicationKeyHolder is the Kind of a KeyHolder that is an Application.
+	ApplicationKeyHolder = "application"
+
+	// EnvironmentKeyHolder is the Kind of a KeyHolder that is an Environment.
+	EnvironmentKeyHolder = "environment"
+)
+
+// KeyFindingType describes the kind of problem that a KeyFinding reports.
+type KeyFindingType string
+
+const (
+	// DuplicateKey findings report distinct keypairs that share a fingerprint.
+	DuplicateKey KeyFindingType = "duplicate"
+
+	// WeakKey findings report keypairs with a weak type or size.
+	WeakKey KeyFindingType = "weak"
+
+	// OldKey findings report keypairs older than the audit's MaxAge.
+	OldKey KeyFindingType = "old"
+
+	// OrphanedKey findings report keypairs that are still present in an
+	// environment after the user that owns them has left the account.
+	OrphanedKey KeyFindingType = "orphaned"
+
+	// InvalidKey findings report keypairs whose public key can't be parsed.
+	InvalidKey KeyFindingType = "invalid"
+)
+
+// KeyAuditPolicy is a data structure that configures a key audit.
+type KeyAuditPolicy struct {
+	// MaxAge is the age after which a key is reported as old. Zero disables
+	// the check.
+	MaxAge time.Duration
+
+	// MinRSABits is the smallest acceptable RSA key size. Zero means 2048.
+	MinRSABits int
+
+	// Now is the time against which key ages are measured. Zero means the
+	// current time.
+	Now time.Time
+}
+
+// KeyHolder is a data structure that identifies a User, Application, or
+// Environment that holds a keypair.
+type KeyHolder struct {
+	Kind string
+	ID   string
+	Name string
+}
+
+// String returns a human-readable description of the holder.
+func (holder KeyHolder) String() string {
+	return fmt.Sprintf("%s %s (%s)", holder.Kind, holder.Name, holder.ID)
+}
+
+// KeyHolding is a data structure that lists the keypairs held by a single
+// KeyHolder.
+type KeyHolding struct {
+	Holder   KeyHolder
+	KeyPairs []*KeyPair
+}
+
+// AuditedKey is a data structure that describes a single keypair and every
+// holder in which it was found.
+type AuditedKey struct {
+	KeyPair     *KeyPair
+	Fingerprint string
+	Holders     []KeyHolder
+}
+
+// KeyFinding is a data structure that describes a problem found by a key
+// audit.
+type KeyFinding struct {
+	Type        KeyFindingType
+	Fingerprint string
+	Keys        []*AuditedKey
+	Holders     []KeyHolder
+	Message     string
+}
+
+// KeyAudit is a data structure that describes the result of auditing a set
+// of KeyHoldings.
+type KeyAudit struct {
+	Policy   KeyAuditPolicy
+	Keys     []*AuditedKey
+	Findings []*KeyFinding
+}
+
+// FindingsOf returns the findings of the given type.
+func (audit *KeyAudit) FindingsOf(kind KeyFindingType) []*KeyFinding {
+	findings := make([]*KeyFinding, 0)
+
+	for _, finding := range audit.Findings {
+		if finding.Type == kind {
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// Holders returns every holder of a key with the given fingerprint. Both MD5
+// and SHA256 fingerprints are accepted.
+func (audit *KeyAudit) Holders(fingerprint string) []KeyHolder {
+	holders := make([]KeyHolder, 0)
+
+	for _, key := range audit.Keys {
+		if key.matches(fingerprint) {
+			holders = appendHolders(holders, key.Holders...)
+		}
+	}
+
+	return holders
+}
+
+// EnvironmentsTrusting returns the environments that hold a key with the
+// given fingerprint.
+func (audit *KeyAudit) EnvironmentsTrusting(fingerprint string) []KeyHolder {
+	environments := make([]KeyHolder, 0)
+
+	for _, holder := range audit.Holders(fingerprint) {
+		if holder.Kind == EnvironmentKeyHolder {
+			environments = append(environments, holder)
+		}
+	}
+
+	return environments
+}
+
+func (key *AuditedKey) matches(fingerprint string) bool {
+	if parsed, err := key.KeyPair.ParsedKey(); err == nil {
+		return parsed.MatchesFingerprint(fingerprint)
+	}
+
+	return strings.EqualFold(key.KeyPair.Fingerprint, fingerprint)
+}
+
+func (key *AuditedKey) heldBy(kind string) []KeyHolder {
+	holders := make([]KeyHolder, 0)
+
+	for _, holder := range key.Holders {
+		if holder.Kind == kind {
+			holders = append(holders, holder)
+		}
+	}
+
+	return holders
+}
+
+// AuditKeys takes the keypairs held by a set of holders, the users that
+// currently belong to the account, and a KeyAuditPolicy. It returns a
+// KeyAudit that reports duplicate, weak, old, invalid, and orphaned keys.
+func AuditKeys(holdings []*KeyHolding, users []*User, policy KeyAuditPolicy) *KeyAudit {
+	if policy.MinRSABits <= 0 {
+		policy.MinRSABits = 2048
+	}
+
+	if policy.Now.IsZero() {
+		policy.Now = time.Now()
+	}
+
+	audit := &KeyAudit{
+		Policy:   policy,
+		Keys:     make([]*AuditedKey, 0),
+		Findings: make([]*KeyFinding, 0),
+	}
+
+	index := make(map[string]*AuditedKey)
+
+	for _, holding := range holdings {
+		for _, keyPair := range holding.KeyPairs {
+			id := auditIdentity(keyPair)
+
+			key, seen := index[id]
+			if !seen {
+				key = &AuditedKey{KeyPair: keyPair, Fingerprint: auditFingerprint(keyPair)}
+				index[id] = key
+				audit.Keys = append(audit.Keys, key)
+			}
+
+			key.Holders = appendHolders(key.Holders, holding.Holder)
+		}
+	}
+
+	current := make(map[string]bool)
+	for _, user := range users {
+		current[user.ID] = true
+	}
+
+	byFingerprint := make(map[string][]*AuditedKey)
+
+	for _, key := range audit.Keys {
+		byFingerprint[key.Fingerprint] = append(byFingerprint[key.Fingerprint], key)
+		audit.inspect(key, current)
+	}
+
+	for fingerprint, keys := range byFingerprint {
+		if len(keys) < 2 {
+			continue
+		}
+
+		holders := make([]KeyHolder, 0)
+		for _, key := range keys {
+			holders = appendHolders(holders, key.Holders...)
+		}
+
+		audit.report(&KeyFinding{
+			Type:        DuplicateKey,
+			Fingerprint: fingerprint,
+			Keys:        keys,
+			Holders:     holders,
+			Message:     fmt.Sprintf("%d keypairs share this fingerprint", len(keys)),
+		})
+	}
+
+	sort.SliceStable(audit.Findings, func(i, j int) bool {
+		left, right := audit.Findings[i], audit.Findings[j]
+
+		if left.Type != right.Type {
+			return left.Type < right.Type
+		}
+
+		return left.Fingerprint < right.Fingerprint
+	})
+
+	return audit
+}
+
+func (audit *KeyAudit) inspect(key *AuditedKey, current map[string]bool) {
+	finding := func(kind KeyFindingType, holders []KeyHolder, message string) {
+		audit.report(&KeyFinding{
+			Type:        kind,
+			Fingerprint: key.Fingerprint,
+			Keys:        []*AuditedKey{key},
+			Holders:     holders,
+			Message:     message,
+		})
+	}
+
+	keyType := ""
+	if fields := strings.Fields(key.KeyPair.PublicKey); len(fields) > 0 {
+		keyType = fields[0]
+	}
+
+	parsed, err := key.KeyPair.ParsedKey()
+
+	switch {
+	case keyType == "ssh-dss":
+		finding(WeakKey, key.Holders, "DSA keys are no longer considered secure")
+	case err != nil:
+		finding(InvalidKey, key.Holders, err.Error())
+	case parsed.Type == "ssh-rsa" && parsed.Bits < audit.Policy.MinRSABits:
+		finding(
+			WeakKey,
+			key.Holders,
+			fmt.Sprintf("%d-bit RSA key is smaller than %d bits", parsed.Bits, audit.Policy.MinRSABits),
+		)
+	}
+
+	if audit.Policy.MaxAge > 0 {
+		if created, err := parseTimestamp(key.KeyPair.CreatedAt); err == nil {
+			age := audit.Policy.Now.Sub(created)
+
+			if age > audit.Policy.MaxAge {
+				finding(
+					OldKey,
+					key.Holders,
+					fmt.Sprintf("key is %d days old", int(age.Hours()/24)),
+				)
+			}
+		}
+	}
+
+	if owner := ownerID(key.KeyPair); len(owner) > 0 && !current[owner] {
+		if environments := key.heldBy(EnvironmentKeyHolder); len(environments) > 0 {
+			finding(
+				OrphanedKey,
+				environments,
+				fmt.Sprintf("owning user %s is no longer on the account", owner),
+			)
+		}
+	}
+}
+
+func (audit *KeyAudit) report(finding *KeyFinding) {
+	audit.Findings = append(audit.Findings, finding)
+}
+
+func auditIdentity(keyPair *KeyPair) string {
+	if keyPair.ID != 0 {
+		return "id:" + strconv.Itoa(keyPair.ID)
+	}
+
+	return "fingerprint:" + auditFingerprint(keyPair)
+}
+
+func auditFingerprint(keyPair *KeyPair) string {
+	if parsed, err := keyPair.ParsedKey(); err == nil {
+		return parsed.SHA256Fingerprint()
+	}
+
+	if len(keyPair.Fingerprint) > 0 {
+		return keyPair.Fingerprint
+	}
+
+	return "unknown:" + strings.TrimSpace(keyPair.PublicKey)
+}
+
+func ownerID(keyPair *KeyPair) string {
+	if len(keyPair.UserURL) == 0 {
+		return ""
+	}
+
+	path := pathFor(keyPair.UserURL)
+
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+func appendHolders(holders []KeyHolder, more ...KeyHolder) []KeyHolder {
+	for _, holder := range more {
+		found := false
+
+		for _, existing := range holders {
+			if existing == holder {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			holders = append(holders, holder)
+		}
+	}
+
+	return holders
+}
+
+// Audit walks the users, environments, and applications of the given Account,
+// gathering their keypairs, and returns the resulting KeyAudit. If any of
+// them can't be retrieved, an error is returned rather than an incomplete
+// audit.
+func (service *KeyPairService) Audit(account *Account, policy KeyAuditPolicy) (*KeyAudit, error) {
+	if account == nil || len(account.ID) == 0 {
+		return nil, fmt.Errorf("No valid account given")
+	}
+
+	holdings := make([]*KeyHolding, 0)
+
+	users, err := NewUserService(service.Driver).fetch("accounts/"+account.ID+"/users", nil)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't retrieve the users of account %s: %s", account.ID, err)
+	}
+
+	for _, user := range users {
+		keyPairs, err := service.fetch("users/"+user.ID+"/keypairs", nil)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't retrieve the keypairs of user %s: %s", user.ID, err)
+		}
+
+		holdings = append(holdings, &KeyHolding{
+			Holder:   KeyHolder{Kind: UserKeyHolder, ID: user.ID, Name: user.Name},
+			KeyPairs: keyPairs,
+		})
+	}
+
+	applications, err := NewApplicationService(service.Driver).fetch("accounts/"+account.ID+"/applications", nil)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't retrieve the applications of account %s: %s", account.ID, err)
+	}
+
+	for _, application := range applications {
+		keyPairs, err := service.fetch("applications/"+strconv.Itoa(application.ID)+"/keypairs", nil)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't retrieve the keypairs of application %d: %s", application.ID, err)
+		}
+
+		holdings = append(holdings, &KeyHolding{
+			Holder: KeyHolder{
+				Kind: ApplicationKeyHolder,
+				ID:   strconv.Itoa(application.ID),
+				Name: application.Name,
+			},
+			KeyPairs: keyPairs,
+		})
+	}
+
+	environments, err := NewEnvironmentService(service.Driver).fetch("accounts/"+account.ID+"/environments", nil)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't retrieve the environments of account %s: %s", account.ID, err)
+	}
+
+	for _, environment := range environments {
+		keyPairs, err := service.fetch("environments/"+strconv.Itoa(environment.ID)+"/keypairs", nil)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't retrieve the keypairs of environment %d: %s", environment.ID, err)
+		}
+
+		holdings = append(holdings, &KeyHolding{
+			Holder: KeyHolder{
+				Kind: EnvironmentKeyHolder,
+				ID:   strconv.Itoa(environment.ID),
+				Name: environment.Name,
+			},
+			KeyPairs: keyPairs,
+		})
+	}
+
+	return AuditKeys(holdings, users, policy), nil
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
@@ -0,0 +1,216 @@
+package eygo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+const testWeakRSAKey = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQCwRXGqY2bMtFzxiN7MNC1dShae4yw9ltoqtfSkuTpyrriIxarAfnuw4e8YVY3F9Yzp9SoxdF7QYdcNq83NoN1eWn5R0e7i+v/z8KC6VlVS/H4AscCzvwi9dnmvdOMBp5r73eLIeAhKyxozaltkOQ1VhIqMWKOu+ffnCWzGqU/jcQ== weak"
+
+func TestAuditKeys(t *testing.T) {
+	now := time.Date(2018, 6, 15, 0, 0, 0, 0, time.UTC)
+	policy := KeyAuditPolicy{MaxAge: 365 * 24 * time.Hour, Now: now}
+
+	alice := &User{ID: "1", Name: "Alice"}
+	bob := KeyHolder{Kind: UserKeyHolder, ID: "2", Name: "Bob"}
+	production := KeyHolder{Kind: EnvironmentKeyHolder, ID: "10", Name: "production"}
+	staging := KeyHolder{Kind: EnvironmentKeyHolder, ID: "11", Name: "staging"}
+	app := KeyHolder{Kind: ApplicationKeyHolder, ID: "20", Name: "app"}
+
+	laptop := &KeyPair{
+		ID:        1,
+		PublicKey: testEd25519Key,
+		UserURL:   "https://api.engineyard.com/users/1",
+		CreatedAt: "2018-01-01T00:00:00Z",
+	}
+
+	oldLaptop := &KeyPair{
+		ID:        2,
+		PublicKey: testRSAKey,
+		UserURL:   "https://api.engineyard.com/users/2",
+		CreatedAt: "2016-01-01T00:00:00Z",
+	}
+
+	copied := &KeyPair{ID: 3, PublicKey: testEd25519Key, CreatedAt: "2018-02-01T00:00:00Z"}
+	weak := &KeyPair{ID: 4, PublicKey: testWeakRSAKey, CreatedAt: "2018-03-01T00:00:00Z"}
+	dsa := &KeyPair{ID: 5, PublicKey: "ssh-dss AAAAB3NzaC1kc3M= legacy"}
+	broken := &KeyPair{ID: 6, PublicKey: "ssh-rsa nope", Fingerprint: "aa:bb"}
+
+	holdings := []*KeyHolding{
+		{Holder: KeyHolder{Kind: UserKeyHolder, ID: "1", Name: "Alice"}, KeyPairs: []*KeyPair{laptop}},
+		{Holder: app, KeyPairs: []*KeyPair{copied, weak}},
+		{Holder: production, KeyPairs: []*KeyPair{laptop, oldLaptop, dsa}},
+		{Holder: staging, KeyPairs: []*KeyPair{oldLaptop, broken}},
+	}
+
+	audit := AuditKeys(holdings, []*User{alice}, policy)
+
+	t.Run("it lists each keypair once", func(t *testing.T) {
+		if len(audit.Keys) != 6 {
+			t.Errorf("Expected 6 keys, got %d", len(audit.Keys))
+		}
+	})
+
+	t.Run("it reports keypairs that share a fingerprint", func(t *testing.T) {
+		duplicates := audit.FindingsOf(DuplicateKey)
+
+		if len(duplicates) != 1 {
+			t.Fatalf("Expected 1 duplicate finding, got %d", len(duplicates))
+		}
+
+		if len(duplicates[0].Keys) != 2 || len(duplicates[0].Holders) != 3 {
+			t.Errorf("Unexpected duplicate finding: %v", duplicates[0])
+		}
+	})
+
+	t.Run("it reports weak keys", func(t *testing.T) {
+		weakFindings := audit.FindingsOf(WeakKey)
+
+		if len(weakFindings) != 2 {
+			t.Fatalf("Expected 2 weak findings, got %d", len(weakFindings))
+		}
+
+		for _, finding := range weakFindings {
+			id := finding.Keys[0].KeyPair.ID
+			if id != weak.ID && id != dsa.ID {
+				t.Errorf("Unexpected weak key %d", id)
+			}
+		}
+	})
+
+	t.Run("it reports old keys", func(t *testing.T) {
+		old := audit.FindingsOf(OldKey)
+
+		if len(old) != 1 || old[0].Keys[0].KeyPair != oldLaptop {
+			t.Errorf("Expected only the old laptop key to be old, got %v", old)
+		}
+	})
+
+	t.Run("it reports invalid keys", func(t *testing.T) {
+		invalid := audit.FindingsOf(InvalidKey)
+
+		if len(invalid) != 1 || invalid[0].Keys[0].KeyPair != broken {
+			t.Errorf("Expected only the broken key to be invalid, got %v", invalid)
+		}
+	})
+
+	t.Run("it reports environment keys whose owner is gone", func(t *testing.T) {
+		orphaned := audit.FindingsOf(OrphanedKey)
+
+		if len(orphaned) != 1 || orphaned[0].Keys[0].KeyPair != oldLaptop {
+			t.Fatalf("Expected only the old laptop key to be orphaned, got %v", orphaned)
+		}
+
+		if len(orphaned[0].Holders) != 2 {
+			t.Errorf("Expected 2 environments, got %v", orphaned[0].Holders)
+		}
+	})
+
+	t.Run("it knows which environments trust a key", func(t *testing.T) {
+		trusting := audit.EnvironmentsTrusting("0a:b6:40:98:e8:68:de:8c:94:89:94:65:c2:bb:b3:41")
+
+		if len(trusting) != 2 || trusting[0] != production || trusting[1] != staging {
+			t.Errorf("Expected production and staging, got %v", trusting)
+		}
+	})
+
+	t.Run("it knows every holder of a key", func(t *testing.T) {
+		holders := audit.Holders("SHA256:M6YBcMHXCi/cOcbC9tahhoRI8WpThMPGeQ8DtAwpy/s")
+
+		if len(holders) != 3 {
+			t.Errorf("Expected 3 holders, got %v", holders)
+		}
+
+		for _, holder := range holders {
+			if holder == bob {
+				t.Errorf("Didn't expect Bob to hold the key")
+			}
+		}
+	})
+}
+
+func TestKeyPairService_Audit(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewKeyPairService(driver)
+	account := &Account{ID: "1"}
+
+	t.Run("without an account", func(t *testing.T) {
+		if _, err := service.Audit(nil, KeyAuditPolicy{}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("for an account", func(t *testing.T) {
+		user := &User{ID: "1", Name: "Alice"}
+		environment := &Environment{ID: 10, Name: "production"}
+		application := &Application{ID: 20, Name: "app"}
+		departed := &KeyPair{ID: 2, PublicKey: testRSAKey, UserURL: "https://api.engineyard.com/users/2"}
+
+		stubAccountUsers(driver, account, user)
+		stubAccountEnvironments(driver, account, environment)
+		stubAccountApplications(driver, account, application)
+		stubUserKeyPairs(driver, user, &KeyPair{ID: 1, PublicKey: testEd25519Key})
+		stubApplicationKeyPairs(driver, application, &KeyPair{ID: 3, PublicKey: testECDSAKey})
+		stubEnvironmentKeyPairs(driver, environment, departed)
+
+		audit, err := service.Audit(account, KeyAuditPolicy{})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it gathers keys from users, applications, and environments", func(t *testing.T) {
+			if len(audit.Keys) != 3 {
+				t.Errorf("Expected 3 keys, got %d", len(audit.Keys))
+			}
+		})
+
+		t.Run("it reports the departed user's key", func(t *testing.T) {
+			orphaned := audit.FindingsOf(OrphanedKey)
+
+			if len(orphaned) != 1 || orphaned[0].Keys[0].KeyPair.ID != departed.ID {
+				t.Errorf("Expected the departed key to be orphaned, got %v", orphaned)
+			}
+		})
+	})
+
+	t.Run("when the account's users can't be retrieved", func(t *testing.T) {
+		driver.Reset()
+		environment := &Environment{ID: 10, Name: "production"}
+		owned := &KeyPair{ID: 2, PublicKey: testRSAKey, UserURL: "https://api.engineyard.com/users/42"}
+
+		driver.AddResponse("get", "accounts/1/users", Response{Error: fmt.Errorf("Oh no!")})
+		stubAccountEnvironments(driver, account, environment)
+		stubAccountApplications(driver, account)
+		stubEnvironmentKeyPairs(driver, environment, owned)
+
+		audit, err := service.Audit(account, KeyAuditPolicy{})
+
+		t.Run("it returns an error instead of findings", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+
+			if audit != nil {
+				t.Errorf("Expected no audit, got %v", audit.Findings)
+			}
+		})
+	})
+
+	t.Run("when a holder's keypairs can't be retrieved", func(t *testing.T) {
+		driver.Reset()
+		environment := &Environment{ID: 10, Name: "production"}
+
+		stubAccountUsers(driver, account)
+		stubAccountApplications(driver, account)
+		stubAccountEnvironments(driver, account, environment)
+		driver.AddResponse("get", "environments/10/keypairs", Response{Error: fmt.Errorf("Oh no!")})
+
+		if _, err := service.Audit(account, KeyAuditPolicy{}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
@@ -2,6 +2,7 @@ package eygo
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 )
 
@@ -17,6 +18,33 @@ type KeyPair struct {
 	ApplicationURL string `json:"application,omitempty"`
 }
 
+// ParsedKey returns the PublicKey described by the KeyPair's PublicKey. If
+// the key can't be parsed, an error is returned.
+func (keyPair *KeyPair) ParsedKey() (*PublicKey, error) {
+	return ParsePublicKey(keyPair.PublicKey)
+}
+
+// Verify checks that the KeyPair's PublicKey is a valid OpenSSH public key
+// and, if the KeyPair has a Fingerprint, that the Fingerprint belongs to that
+// key. If either check fails, an error is returned.
+func (keyPair *KeyPair) Verify() error {
+	key, err := keyPair.ParsedKey()
+	if err != nil {
+		return err
+	}
+
+	if len(keyPair.Fingerprint) > 0 && !key.MatchesFingerprint(keyPair.Fingerprint) {
+		return fmt.Errorf(
+			"Fingerprint %s doesn't match public key (%s, %s)",
+			keyPair.Fingerprint,
+			key.MD5Fingerprint(),
+			key.SHA256Fingerprint(),
+		)
+	}
+
+	return nil
+}
+
 // KeyPairService is a repository that one can use to create, retrieve, delete,
 // and perform other operations on KeyPair records on the API.
 type KeyPairService struct {
@@ -51,23 +79,124 @@ func (service *KeyPairService) ForApplication(application *Application, params P
 	return service.collection("applications/"+strconv.Itoa(application.ID)+"/keypairs", params)
 }
 
+// CreateForUser takes a User and a KeyPair, uploads the KeyPair for the given
+// User on the upstream API, and returns the newly created KeyPair. The
+// PublicKey is validated before it is uploaded, and an error is returned if
+// the API reports a fingerprint that doesn't belong to it.
+func (service *KeyPairService) CreateForUser(user *User, keyPair *KeyPair) (*KeyPair, error) {
+	if user == nil || len(user.ID) == 0 {
+		return nil, fmt.Errorf("No valid user given")
+	}
+
+	return service.create("users/"+user.ID+"/keypairs", keyPair)
+}
+
+// CreateForApplication takes an Application and a KeyPair, uploads the
+// KeyPair for the given Application on the upstream API, and returns the
+// newly created KeyPair. The PublicKey is validated before it is uploaded,
+// and an error is returned if the API reports a fingerprint that doesn't
+// belong to it.
+func (service *KeyPairService) CreateForApplication(application *Application, keyPair *KeyPair) (*KeyPair, error) {
+	if application == nil || application.ID == 0 {
+		return nil, fmt.Errorf("No valid application given")
+	}
+
+	return service.create(
+		"applications/"+strconv.Itoa(application.ID)+"/keypairs",
+		keyPair,
+	)
+}
+
+// Destroy deletes the given KeyPair from the upstream API. If there are
+// issues along the way, an error is returned.
+func (service *KeyPairService) Destroy(keyPair *KeyPair) error {
+	if keyPair == nil || keyPair.ID == 0 {
+		return fmt.Errorf("No valid keypair given")
+	}
+
+	response := service.Driver.Delete("keypairs/"+strconv.Itoa(keyPair.ID), Params{})
+	if !response.Okay() {
+		return response.Error
+	}
+
+	return nil
+}
+
+// create validates the KeyPair locally before uploading it to the given path,
+// then checks that the fingerprint the API reports for the new KeyPair
+// belongs to the key that was uploaded.
+func (service *KeyPairService) create(path string, keyPair *KeyPair) (*KeyPair, error) {
+	if keyPair == nil || len(keyPair.Name) == 0 {
+		return nil, fmt.Errorf("A keypair requires a name")
+	}
+
+	if err := keyPair.Verify(); err != nil {
+		return nil, err
+	}
+
+	wrapper := struct {
+		KeyPair *KeyPair `json:"keypair,omitempty"`
+	}{KeyPair: &KeyPair{Name: keyPair.Name, PublicKey: keyPair.PublicKey}}
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	response := service.Driver.Post(path, nil, body)
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	created := struct {
+		KeyPair *KeyPair `json:"keypair,omitempty"`
+	}{}
+
+	if err := json.Unmarshal(response.Pages[0], &created); err != nil {
+		return nil, err
+	}
+
+	if created.KeyPair == nil {
+		return nil, fmt.Errorf("The API returned no keypair")
+	}
+
+	key, _ := keyPair.ParsedKey()
+	if len(created.KeyPair.Fingerprint) > 0 && !key.MatchesFingerprint(created.KeyPair.Fingerprint) {
+		return created.KeyPair, fmt.Errorf(
+			"API fingerprint %s doesn't match uploaded key (%s)",
+			created.KeyPair.Fingerprint,
+			key.MD5Fingerprint(),
+		)
+	}
+
+	return created.KeyPair, nil
+}
+
 func (service *KeyPairService) collection(path string, params Params) []*KeyPair {
+	keyPairs, _ := service.fetch(path, params)
+
+	return keyPairs
+}
+
+func (service *KeyPairService) fetch(path string, params Params) ([]*KeyPair, error) {
 	keyPairs := make([]*KeyPair, 0)
 	response := service.Driver.Get(path, params)
 
-	if response.Okay() {
-		for _, page := range response.Pages {
-			wrapper := struct {
-				KeyPairs []*KeyPair `json:"keyPairs,omitempty"`
-			}{}
+	if !response.Okay() {
+		return keyPairs, response.Error
+	}
 
-			if err := json.Unmarshal(page, &wrapper); err == nil {
-				keyPairs = append(keyPairs, wrapper.KeyPairs...)
-			}
+	for _, page := range response.Pages {
+		wrapper := struct {
+			KeyPairs []*KeyPair `json:"keyPairs,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(page, &wrapper); err == nil {
+			keyPairs = append(keyPairs, wrapper.KeyPairs...)
 		}
 	}
 
-	return keyPairs
+	return keyPairs, nil
 }
 
 /*
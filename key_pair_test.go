@@ -2,6 +2,7 @@ package eygo
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"testing"
 )
@@ -228,6 +229,181 @@ func TestKeyPairService_ForApplication(t *testing.T) {
 
 }
 
+func TestKeyPair_Verify(t *testing.T) {
+	t.Run("it accepts a valid key without a fingerprint", func(t *testing.T) {
+		if err := (&KeyPair{PublicKey: testRSAKey}).Verify(); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("it accepts a valid key with its fingerprint", func(t *testing.T) {
+		keyPair := &KeyPair{
+			PublicKey:   testEd25519Key,
+			Fingerprint: "dc:5b:20:db:8e:40:9e:8b:53:35:e5:b1:80:99:f6:63",
+		}
+
+		if err := keyPair.Verify(); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("it rejects a mismatched fingerprint", func(t *testing.T) {
+		keyPair := &KeyPair{
+			PublicKey:   testRSAKey,
+			Fingerprint: "dc:5b:20:db:8e:40:9e:8b:53:35:e5:b1:80:99:f6:63",
+		}
+
+		if err := keyPair.Verify(); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("it rejects an invalid key", func(t *testing.T) {
+		if err := (&KeyPair{PublicKey: "ssh-rsa nope"}).Verify(); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestKeyPairService_CreateForUser(t *testing.T) {
+	user := &User{ID: "1"}
+	driver := NewMockDriver()
+	service := NewKeyPairService(driver)
+
+	t.Run("with an invalid public key", func(t *testing.T) {
+		_, err := service.CreateForUser(user, &KeyPair{Name: "laptop", PublicKey: "ssh-rsa nope"})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it doesn't upload the key", func(t *testing.T) {
+			if len(driver.Requests("post")) != 0 {
+				t.Errorf("Expected no post requests")
+			}
+		})
+	})
+
+	t.Run("when the API agrees on the fingerprint", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse(
+			"post",
+			"users/1/keypairs",
+			Response{Pages: [][]byte{[]byte(`{"keypair": {"id": 7, "name": "laptop", "fingerprint": "0a:b6:40:98:e8:68:de:8c:94:89:94:65:c2:bb:b3:41"}}`)}},
+		)
+
+		result, err := service.CreateForUser(user, &KeyPair{Name: "laptop", PublicKey: testRSAKey})
+
+		t.Run("it returns the new keypair", func(t *testing.T) {
+			if result == nil || result.ID != 7 {
+				t.Errorf("Expected keypair 7, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+	})
+
+	t.Run("when the API reports a different fingerprint", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse(
+			"post",
+			"users/1/keypairs",
+			Response{Pages: [][]byte{[]byte(`{"keypair": {"id": 8, "fingerprint": "00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00"}}`)}},
+		)
+
+		result, err := service.CreateForUser(user, &KeyPair{Name: "laptop", PublicKey: testRSAKey})
+
+		t.Run("it still returns the created keypair", func(t *testing.T) {
+			if result == nil || result.ID != 8 {
+				t.Errorf("Expected keypair 8, got %v", result)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestKeyPairService_CreateForApplication(t *testing.T) {
+	application := &Application{ID: 3}
+	driver := NewMockDriver()
+	service := NewKeyPairService(driver)
+
+	t.Run("without a name", func(t *testing.T) {
+		if _, err := service.CreateForApplication(application, &KeyPair{PublicKey: testECDSAKey}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with a valid keypair", func(t *testing.T) {
+		driver.AddResponse(
+			"post",
+			"applications/3/keypairs",
+			Response{Pages: [][]byte{[]byte(`{"keypair": {"id": 9, "fingerprint": "SHA256:WDky3GHQoYHtf/gNrr0EPPjMWNtYut+Jv/xP4lbG4Y8"}}`)}},
+		)
+
+		result, err := service.CreateForApplication(application, &KeyPair{Name: "deploy", PublicKey: testECDSAKey})
+
+		t.Run("it sends the name and public key", func(t *testing.T) {
+			bodies := driver.Bodies("post")
+			expected := `{"keypair":{"name":"deploy","public_key":"` + testECDSAKey + `"}}`
+
+			if len(bodies) != 1 || string(bodies[0]) != expected {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns the new keypair", func(t *testing.T) {
+			if result == nil || result.ID != 9 {
+				t.Errorf("Expected keypair 9, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+	})
+}
+
+func TestKeyPairService_Destroy(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewKeyPairService(driver)
+
+	t.Run("without an ID", func(t *testing.T) {
+		if err := service.Destroy(&KeyPair{}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when the API accepts the deletion", func(t *testing.T) {
+		driver.AddResponse("delete", "keypairs/7", Response{})
+
+		if err := service.Destroy(&KeyPair{ID: 7}); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("when the API rejects the deletion", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "keypairs/7", Response{Error: fmt.Errorf("Oh no!")})
+
+		if err := service.Destroy(&KeyPair{ID: 7}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
 func stubKeyPairs(driver *MockDriver, keyPairs ...*KeyPair) {
 	pages := make([][]byte, 0)
 
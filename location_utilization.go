@@ -0,0 +1,167 @@
+package eygo
+
+import (
+	"fmt"
+)
+
+// Usage is a data structure that compares the number of resources in use
+// against the number allowed. When Limited is false, no limit is known and
+// Allowed is meaningless.
+type Usage struct {
+	Used    int
+	Allowed int
+	Limited bool
+}
+
+// Headroom returns the number of resources that can still be used before
+// reaching the limit. It is negative when the limit has been exceeded, and
+// zero when there is no known limit.
+func (usage Usage) Headroom() int {
+	if !usage.Limited {
+		return 0
+	}
+
+	return usage.Allowed - usage.Used
+}
+
+// Exceeded returns true if more resources are in use than are allowed.
+func (usage Usage) Exceeded() bool {
+	return usage.Limited && usage.Used > usage.Allowed
+}
+
+// String returns a summary of the usage, such as "3/10 (7 free)".
+func (usage Usage) String() string {
+	if !usage.Limited {
+		return fmt.Sprintf("%d/unlimited", usage.Used)
+	}
+
+	return fmt.Sprintf("%d/%d (%d free)", usage.Used, usage.Allowed, usage.Headroom())
+}
+
+// LocationUtilization is a data structure that reports the server and
+// address usage of a ProviderLocation, including the usage of all of its
+// descendants.
+type LocationUtilization struct {
+	Location  *ProviderLocation
+	Servers   Usage
+	Addresses Usage
+	Children  []*LocationUtilization
+}
+
+// Utilization takes a location tree, the servers, and the addresses of a
+// provider, and returns the usage of each location in the tree. Resources are
+// matched to locations by their Location, and each location's usage includes
+// that of its descendants.
+func Utilization(tree []*LocationNode, servers []*Server, addresses []*Address) []*LocationUtilization {
+	serverCounts := make(map[string]int)
+	for _, server := range servers {
+		serverCounts[server.Location]++
+	}
+
+	addressCounts := make(map[string]int)
+	for _, address := range addresses {
+		addressCounts[address.Location]++
+	}
+
+	utilization := make([]*LocationUtilization, 0, len(tree))
+	for _, node := range tree {
+		utilization = append(utilization, utilize(node, serverCounts, addressCounts))
+	}
+
+	return utilization
+}
+
+func utilize(node *LocationNode, servers map[string]int, addresses map[string]int) *LocationUtilization {
+	location := node.Location
+	result := &LocationUtilization{
+		Location:  location,
+		Servers:   Usage{Used: servers[location.LocationID]},
+		Addresses: Usage{Used: addresses[location.LocationID]},
+		Children:  make([]*LocationUtilization, 0, len(node.Children)),
+	}
+
+	if limits := location.Limits; limits != nil {
+		result.Servers.Allowed = limits.Servers
+		result.Servers.Limited = limits.Servers > 0
+		result.Addresses.Allowed = limits.Addresses
+		result.Addresses.Limited = limits.Addresses > 0
+	}
+
+	for _, child := range node.Children {
+		utilization := utilize(child, servers, addresses)
+		result.Servers.Used += utilization.Servers.Used
+		result.Addresses.Used += utilization.Addresses.Used
+		result.Children = append(result.Children, utilization)
+	}
+
+	return result
+}
+
+// Utilization builds the location tree for the given Provider and reports
+// the usage of each location by the active servers and the addresses that
+// the given Account has on that Provider. Resources that don't report a
+// provider aren't counted against any provider. If any of the locations,
+// servers, or addresses can't be retrieved, an error is returned.
+func (service *ProviderLocationService) Utilization(account *Account, provider *Provider) ([]*LocationUtilization, error) {
+	if account == nil || len(account.ID) == 0 {
+		return nil, fmt.Errorf("No valid account given")
+	}
+
+	if provider == nil || provider.ID == 0 {
+		return nil, fmt.Errorf("No valid provider given")
+	}
+
+	all, err := NewServerService(service.Driver).fetch("accounts/"+account.ID+"/servers", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	servers := make([]*Server, 0)
+	for _, server := range all {
+		if onProvider(server.ProviderURL, provider) && len(server.DeprovisionedAt) == 0 && len(server.DeletedAt) == 0 {
+			servers = append(servers, server)
+		}
+	}
+
+	allocated, err := NewAddressService(service.Driver).fetch("accounts/"+account.ID+"/addresses", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]*Address, 0)
+	for _, address := range allocated {
+		if onProvider(address.ProviderURL, provider) {
+			addresses = append(addresses, address)
+		}
+	}
+
+	tree, err := service.Tree(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	return Utilization(tree, servers, addresses), nil
+}
+
+// onProvider returns true if the given provider URL refers to the given
+// Provider, and false otherwise, including when the URL is unknown.
+func onProvider(providerURL string, provider *Provider) bool {
+	return len(providerURL) > 0 &&
+		pathFor(providerURL) == fmt.Sprintf("providers/%d", provider.ID)
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
@@ -0,0 +1,175 @@
+package eygo
+
+import (
+	"testing"
+)
+
+func TestUsage(t *testing.T) {
+	t.Run("with a limit", func(t *testing.T) {
+		usage := Usage{Used: 3, Allowed: 10, Limited: true}
+
+		if usage.Headroom() != 7 || usage.Exceeded() {
+			t.Errorf("Expected 7 free, got %s", usage)
+		}
+
+		if usage.String() != "3/10 (7 free)" {
+			t.Errorf("Unexpected summary: %s", usage)
+		}
+	})
+
+	t.Run("over the limit", func(t *testing.T) {
+		usage := Usage{Used: 12, Allowed: 10, Limited: true}
+
+		if usage.Headroom() != -2 || !usage.Exceeded() {
+			t.Errorf("Expected the limit to be exceeded, got %s", usage)
+		}
+	})
+
+	t.Run("without a limit", func(t *testing.T) {
+		usage := Usage{Used: 12}
+
+		if usage.Exceeded() || usage.String() != "12/unlimited" {
+			t.Errorf("Expected no limit, got %s", usage)
+		}
+	})
+}
+
+func TestUtilization(t *testing.T) {
+	east := &ProviderLocation{ID: "1", LocationID: "us-east-1", Limits: &Limits{Servers: 5, Addresses: 2}}
+	eastA := &ProviderLocation{ID: "3", LocationID: "us-east-1a"}
+	eastB := &ProviderLocation{ID: "4", LocationID: "us-east-1b", Limits: &Limits{Servers: 1}}
+
+	tree := []*LocationNode{
+		{Location: east, Children: []*LocationNode{{Location: eastA}, {Location: eastB}}},
+	}
+
+	servers := []*Server{
+		{ID: 1, Location: "us-east-1a"},
+		{ID: 2, Location: "us-east-1a"},
+		{ID: 3, Location: "us-east-1b"},
+		{ID: 4, Location: "us-east-1b"},
+		{ID: 5, Location: "eu-west-1a"},
+	}
+
+	addresses := []*Address{
+		{ID: 1, Location: "us-east-1"},
+		{ID: 2, Location: "us-east-1b"},
+	}
+
+	utilization := Utilization(tree, servers, addresses)
+
+	t.Run("it reports the usage of each root", func(t *testing.T) {
+		if len(utilization) != 1 {
+			t.Fatalf("Expected 1 root, got %d", len(utilization))
+		}
+
+		root := utilization[0]
+
+		if root.Servers != (Usage{Used: 4, Allowed: 5, Limited: true}) {
+			t.Errorf("Unexpected server usage: %s", root.Servers)
+		}
+
+		if root.Addresses != (Usage{Used: 2, Allowed: 2, Limited: true}) {
+			t.Errorf("Unexpected address usage: %s", root.Addresses)
+		}
+	})
+
+	t.Run("it reports the usage of each child", func(t *testing.T) {
+		children := utilization[0].Children
+
+		if len(children) != 2 {
+			t.Fatalf("Expected 2 children, got %d", len(children))
+		}
+
+		if children[0].Servers != (Usage{Used: 2}) {
+			t.Errorf("Unexpected usage for %s: %s", children[0].Location.LocationID, children[0].Servers)
+		}
+
+		if !children[1].Servers.Exceeded() || children[1].Servers.Headroom() != -1 {
+			t.Errorf("Expected %s to be over its limit, got %s", children[1].Location.LocationID, children[1].Servers)
+		}
+	})
+}
+
+func TestProviderLocationService_Utilization(t *testing.T) {
+	account := &Account{ID: "1"}
+	provider := &Provider{ID: 7}
+	driver := NewMockDriver()
+	service := NewProviderLocationService(driver)
+
+	t.Run("without a provider", func(t *testing.T) {
+		if _, err := service.Utilization(account, nil); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("for a provider", func(t *testing.T) {
+		region := &ProviderLocation{ID: "1", LocationID: "us-east-1", Limits: &Limits{Servers: 10, Addresses: 5}}
+		zone := &ProviderLocation{ID: "2", LocationID: "us-east-1a", ParentURL: "https://api.engineyard.com/provider-locations/1"}
+
+		stubProviderProviderLocations(driver, provider, region)
+		stubChildren(driver, region, zone)
+		stubChildren(driver, zone)
+		stubAccountServers(
+			driver,
+			account,
+			&Server{ID: 1, Location: "us-east-1a", ProviderURL: "https://api.engineyard.com/providers/7"},
+			&Server{ID: 2, Location: "us-east-1a", ProviderURL: "https://api.engineyard.com/providers/8"},
+			&Server{ID: 3, Location: "us-east-1a", ProviderURL: "https://api.engineyard.com/providers/7", DeprovisionedAt: "2018-01-01T00:00:00Z"},
+			&Server{ID: 4, Location: "us-east-1a"},
+		)
+		stubAccountAddresss(
+			driver,
+			account,
+			&Address{ID: 1, Location: "us-east-1", ProviderURL: "https://api.engineyard.com/providers/7"},
+			&Address{ID: 2, Location: "us-east-1"},
+		)
+
+		utilization, err := service.Utilization(account, provider)
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it counts only the provider's active resources, ignoring those with unknown providers", func(t *testing.T) {
+			if len(utilization) != 1 {
+				t.Fatalf("Expected 1 root, got %d", len(utilization))
+			}
+
+			if utilization[0].Servers.Used != 1 || utilization[0].Addresses.Used != 1 {
+				t.Errorf("Unexpected usage: %s servers, %s addresses", utilization[0].Servers, utilization[0].Addresses)
+			}
+
+			if utilization[0].Servers.Headroom() != 9 {
+				t.Errorf("Expected 9 free servers, got %d", utilization[0].Servers.Headroom())
+			}
+		})
+	})
+
+	failures := map[string]string{
+		"servers":   "accounts/1/servers",
+		"addresses": "accounts/1/addresses",
+		"locations": "provider-locations/2/provider-locations",
+	}
+
+	for name, path := range failures {
+		t.Run("when the "+name+" can't be retrieved", func(t *testing.T) {
+			region := &ProviderLocation{ID: "1", LocationID: "us-east-1"}
+			zone := &ProviderLocation{ID: "2", LocationID: "us-east-1a"}
+
+			driver.Reset()
+			stubProviderProviderLocations(driver, provider, region)
+			stubChildren(driver, region, zone)
+			stubChildren(driver, zone)
+			stubAccountServers(driver, account)
+			stubAccountAddresss(driver, account)
+			driver.RemoveResponse("get", path)
+
+			if _, err := service.Utilization(account, provider); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 type MockDriver struct {
 	requests  map[string][]string
+	bodies    map[string][][]byte
 	responses *responseCollection
 }
 
@@ -18,14 +19,17 @@ func (driver *MockDriver) Get(path string, params Params) Response {
 }
 
 func (driver *MockDriver) Post(path string, params Params, data []byte) Response {
+	driver.record("post", data)
 	return driver.handle("post", path+driver.processParams(params))
 }
 
 func (driver *MockDriver) Put(path string, params Params, data []byte) Response {
+	driver.record("put", data)
 	return driver.handle("put", path+driver.processParams(params))
 }
 
 func (driver *MockDriver) Patch(path string, params Params, data []byte) Response {
+	driver.record("patch", data)
 	return driver.handle("patch", path+driver.processParams(params))
 }
 
@@ -35,6 +39,7 @@ func (driver *MockDriver) Delete(path string, params Params) Response {
 
 func (driver *MockDriver) Reset() {
 	driver.requests = nil
+	driver.bodies = nil
 	driver.responses = nil
 	driver.setup()
 }
@@ -49,6 +54,16 @@ func (driver *MockDriver) Requests(method string) []string {
 	return requests
 }
 
+func (driver *MockDriver) Bodies(method string) [][]byte {
+	var bodies [][]byte
+
+	driver.setup()
+
+	bodies = append(bodies, driver.bodies[method]...)
+
+	return bodies
+}
+
 func (driver *MockDriver) AddResponse(method string, path string, response Response) {
 	driver.setup()
 
@@ -69,6 +84,12 @@ func (driver *MockDriver) handle(method string, path string) Response {
 	return driver.responses.consume(method, path)
 }
 
+func (driver *MockDriver) record(method string, data []byte) {
+	driver.setup()
+
+	driver.bodies[method] = append(driver.bodies[method], data)
+}
+
 func (driver *MockDriver) processParams(params Params) string {
 	if len(params) > 0 {
 		return "?" + url.Values(params).Encode()
@@ -85,4 +106,8 @@ func (driver *MockDriver) setup() {
 	if driver.requests == nil {
 		driver.requests = make(map[string][]string)
 	}
+
+	if driver.bodies == nil {
+		driver.bodies = make(map[string][][]byte)
+	}
 }
@@ -2,6 +2,8 @@ package eygo
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
 )
 
 // Network is a data structure that models a network on the Engine Yard API
@@ -16,6 +18,36 @@ type Network struct {
 	Location      string `json:"location,omitempty"`
 }
 
+const (
+	// DefaultTenancy is the Network tenancy in which instances share hardware
+	// with other tenants.
+	DefaultTenancy = "default"
+
+	// DedicatedTenancy is the Network tenancy in which instances run on
+	// hardware dedicated to a single tenant.
+	DedicatedTenancy = "dedicated"
+)
+
+// Validate checks that the Network has a valid CIDR, a location, and (if
+// given) a known tenancy.
+func (network *Network) Validate() error {
+	if _, err := ParseCIDR(network.CIDR); err != nil {
+		return err
+	}
+
+	if len(network.Location) == 0 {
+		return fmt.Errorf("A network requires a location")
+	}
+
+	switch network.Tenancy {
+	case "", DefaultTenancy, DedicatedTenancy:
+	default:
+		return fmt.Errorf("Unknown network tenancy: %s", network.Tenancy)
+	}
+
+	return nil
+}
+
 // NetworkService is a repository that one can use to create, retrieve, delete,
 // and perform other operations on Network records on the API.
 type NetworkService struct {
@@ -35,21 +67,80 @@ func (service *NetworkService) All(params Params) []*Network {
 // Find returns the Network record identified by the given network id. If there
 // are errors in retrieving this information, an error is returned as well.
 func (service *NetworkService) Find(id string) (*Network, error) {
-	response := service.Driver.Get("networks/"+id, nil)
-	if response.Okay() {
-		wrapper := struct {
-			Network *Network `json:"network,omitempty"`
-		}{}
+	return service.unwrap(service.Driver.Get("networks/"+id, nil))
+}
 
-		err := json.Unmarshal(response.Pages[0], &wrapper)
-		if err != nil {
-			return nil, err
-		}
+// Create takes a Provider and a Network, saving the Network on the upstream
+// API for the given Provider. The Network is validated before anything is
+// sent. If there are issues along the way, an error is returned. Otherwise,
+// the newly created Network is returned.
+func (service *NetworkService) Create(provider *Provider, network *Network) (*Network, error) {
+	if provider == nil || provider.ID == 0 {
+		return nil, fmt.Errorf("No valid provider given")
+	}
+
+	if network == nil {
+		return nil, fmt.Errorf("No network given")
+	}
+
+	if err := network.Validate(); err != nil {
+		return nil, err
+	}
+
+	wrapper := struct {
+		Network *Network `json:"network,omitempty"`
+	}{
+		Network: &Network{
+			CIDR:     network.CIDR,
+			Tenancy:  network.Tenancy,
+			Location: network.Location,
+		},
+	}
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Post(
+			"providers/"+strconv.Itoa(provider.ID)+"/networks",
+			nil,
+			body,
+		),
+	)
+}
+
+// Destroy deletes the given Network from the upstream API. If there are
+// issues along the way, an error is returned.
+func (service *NetworkService) Destroy(network *Network) error {
+	if network == nil || len(network.ID) == 0 {
+		return fmt.Errorf("No valid network given")
+	}
+
+	response := service.Driver.Delete("networks/"+network.ID, Params{})
+	if !response.Okay() {
+		return response.Error
+	}
+
+	return nil
+}
+
+func (service *NetworkService) unwrap(response Response) (*Network, error) {
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		Network *Network `json:"network,omitempty"`
+	}{}
 
-		return wrapper.Network, nil
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, response.Error
+	return wrapper.Network, nil
 }
 
 func (service *NetworkService) collection(path string, params Params) []*Network {
@@ -2,6 +2,7 @@ package eygo
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 )
 
@@ -107,6 +108,105 @@ func TestNetworkService_Find(t *testing.T) {
 	})
 }
 
+func TestNetwork_Validate(t *testing.T) {
+	t.Run("it accepts a complete network", func(t *testing.T) {
+		network := &Network{CIDR: "10.0.0.0/16", Location: "us-east-1", Tenancy: DedicatedTenancy}
+
+		if err := network.Validate(); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	invalid := map[string]*Network{
+		"missing a CIDR":        {Location: "us-east-1"},
+		"using host bits":       {CIDR: "10.0.0.1/16", Location: "us-east-1"},
+		"missing a location":    {CIDR: "10.0.0.0/16"},
+		"of an unknown tenancy": {CIDR: "10.0.0.0/16", Location: "us-east-1", Tenancy: "shared"},
+	}
+
+	for name, network := range invalid {
+		t.Run("it rejects a network "+name, func(t *testing.T) {
+			if err := network.Validate(); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestNetworkService_Create(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewNetworkService(driver)
+	provider := &Provider{ID: 1}
+
+	t.Run("with an invalid network", func(t *testing.T) {
+		_, err := service.Create(provider, &Network{CIDR: "nope", Location: "us-east-1"})
+
+		if err == nil {
+			t.Errorf("Expected an error")
+		}
+
+		if len(driver.Requests("post")) != 0 {
+			t.Errorf("Expected no post requests")
+		}
+	})
+
+	t.Run("with a valid network", func(t *testing.T) {
+		driver.AddResponse(
+			"post",
+			"providers/1/networks",
+			Response{Pages: [][]byte{[]byte(`{"network": {"id": "net-1", "cidr": "10.0.0.0/16"}}`)}},
+		)
+
+		result, err := service.Create(
+			provider,
+			&Network{ID: "ignored", CIDR: "10.0.0.0/16", Location: "us-east-1", Tenancy: DefaultTenancy},
+		)
+
+		t.Run("it sends the network settings", func(t *testing.T) {
+			bodies := driver.Bodies("post")
+			expected := `{"network":{"cidr":"10.0.0.0/16","tenancy":"default","location":"us-east-1"}}`
+
+			if len(bodies) != 1 || string(bodies[0]) != expected {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns the new network", func(t *testing.T) {
+			if result == nil || result.ID != "net-1" {
+				t.Errorf("Expected network net-1, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestNetworkService_Destroy(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewNetworkService(driver)
+
+	t.Run("when the API accepts the deletion", func(t *testing.T) {
+		driver.AddResponse("delete", "networks/net-1", Response{})
+
+		if err := service.Destroy(&Network{ID: "net-1"}); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("when the API rejects the deletion", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "networks/net-1", Response{Error: fmt.Errorf("Oh no!")})
+
+		if err := service.Destroy(&Network{ID: "net-1"}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
 func stubNetworks(driver *MockDriver, networks ...*Network) {
 	pages := make([][]byte, 0)
 
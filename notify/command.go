@@ -0,0 +1,80 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// CommandSink is a Sink that runs a local command for every message. The
+// message body is written to the command's standard input, and the message
+// details are exposed through EYGO_* environment variables.
+type CommandSink struct {
+	Path string
+	Args []string
+}
+
+// NewCommandSink returns a CommandSink that runs the given command with the
+// given arguments.
+func NewCommandSink(path string, args ...string) *CommandSink {
+	return &CommandSink{Path: path, Args: args}
+}
+
+// Send runs the command for the given Message. If the command can't be run or
+// exits unsuccessfully, an error that includes its output is returned.
+func (sink *CommandSink) Send(message *Message) error {
+	command := exec.Command(sink.Path, sink.Args...)
+	command.Stdin = strings.NewReader(message.Body)
+	command.Env = append(os.Environ(), sink.environment(message)...)
+
+	var output bytes.Buffer
+	command.Stdout = &output
+	command.Stderr = &output
+
+	if err := command.Run(); err != nil {
+		return fmt.Errorf("%s: %s", err, strings.TrimSpace(output.String()))
+	}
+
+	return nil
+}
+
+func (sink *CommandSink) environment(message *Message) []string {
+	env := []string{
+		"EYGO_SUBJECT=" + message.Subject,
+		"EYGO_EVENT=" + string(message.Event),
+	}
+
+	if alert := message.Alert; alert != nil {
+		env = append(
+			env,
+			"EYGO_ALERT_ID="+alert.ID,
+			"EYGO_ALERT_EXTERNAL_ID="+alert.ExternalID,
+			"EYGO_ALERT_NAME="+alert.Name,
+			"EYGO_ALERT_SEVERITY="+alert.Severity,
+			"EYGO_ALERT_TYPE="+alert.Type,
+			"EYGO_ALERT_MESSAGE="+alert.Message,
+			"EYGO_ALERT_RESOURCE="+alert.ResourceURL,
+			"EYGO_ALERT_ENVIRONMENT="+alert.EnvironmentURL,
+		)
+	}
+
+	return env
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ess/eygo"
+)
+
+func TestCommandSink_Send(t *testing.T) {
+	message := &Message{
+		Subject: "[FAILURE] Disk opened",
+		Body:    "Something is wrong",
+		Event:   eygo.AlertOpened,
+		Alert:   &eygo.Alert{ID: "1", Name: "Disk", Severity: "FAILURE"},
+	}
+
+	t.Run("when the command succeeds", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "eygo-notify")
+		if err != nil {
+			t.Fatalf("Couldn't create a temporary directory: %s", err)
+		}
+		defer os.RemoveAll(dir)
+
+		output := filepath.Join(dir, "output")
+		sink := NewCommandSink("sh", "-c", `{ echo "$EYGO_ALERT_ID $EYGO_ALERT_SEVERITY $EYGO_EVENT"; cat; } > "$0"`, output)
+
+		err = sink.Send(message)
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it passes the message to the command", func(t *testing.T) {
+			written, _ := ioutil.ReadFile(output)
+
+			if string(written) != "1 FAILURE opened\nSomething is wrong" {
+				t.Errorf("Unexpected command output: %q", string(written))
+			}
+		})
+	})
+
+	t.Run("when the command fails", func(t *testing.T) {
+		err := NewCommandSink("sh", "-c", "echo broken >&2; exit 3").Send(message)
+
+		t.Run("it returns an error with the command output", func(t *testing.T) {
+			if err == nil || !strings.Contains(err.Error(), "broken") {
+				t.Errorf("Expected an error mentioning the output, got %v", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// EmailSink is a Sink that delivers messages by SMTP.
+type EmailSink struct {
+	// Addr is the host:port of the SMTP server.
+	Addr string
+
+	// Auth is used to authenticate with the SMTP server, if it isn't nil.
+	Auth smtp.Auth
+
+	From string
+	To   []string
+}
+
+// NewEmailSink returns an EmailSink that delivers mail through the SMTP
+// server at the given address.
+func NewEmailSink(addr string, from string, to ...string) *EmailSink {
+	return &EmailSink{Addr: addr, From: from, To: to}
+}
+
+// Send delivers the given Message to every recipient of the sink. If there
+// are issues along the way, an error is returned.
+func (sink *EmailSink) Send(message *Message) error {
+	if len(sink.To) == 0 {
+		return fmt.Errorf("No recipients given")
+	}
+
+	return smtp.SendMail(sink.Addr, sink.Auth, sink.From, sink.To, sink.format(message))
+}
+
+func (sink *EmailSink) format(message *Message) []byte {
+	var mail bytes.Buffer
+
+	fmt.Fprintf(&mail, "From: %s\r\n", sink.From)
+	fmt.Fprintf(&mail, "To: %s\r\n", strings.Join(sink.To, ", "))
+	fmt.Fprintf(&mail, "Subject: %s\r\n", headerSafe(message.Subject))
+	mail.WriteString("MIME-Version: 1.0\r\n")
+	mail.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	mail.WriteString("\r\n")
+	mail.WriteString(strings.Replace(message.Body, "\n", "\r\n", -1))
+
+	return mail.Bytes()
+}
+
+func headerSafe(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
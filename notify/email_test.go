@@ -0,0 +1,144 @@
+package notify
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ess/eygo"
+)
+
+// smtpStandIn is a minimal SMTP server that accepts a single message and
+// records the envelope and data that it receives.
+type smtpStandIn struct {
+	listener   net.Listener
+	from       string
+	recipients []string
+	data       string
+	done       chan struct{}
+}
+
+func newSMTPStandIn(t *testing.T) *smtpStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Couldn't start the SMTP stand-in: %s", err)
+	}
+
+	server := &smtpStandIn{listener: listener, done: make(chan struct{})}
+	go server.serve()
+
+	return server
+}
+
+func (server *smtpStandIn) serve() {
+	defer close(server.done)
+
+	conn, err := server.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.TrimSpace(line))
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			server.from = strings.Trim(strings.TrimSpace(line)[10:], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			server.recipients = append(server.recipients, strings.Trim(strings.TrimSpace(line)[8:], "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 Go ahead")
+
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				if dataLine == ".\r\n" {
+					break
+				}
+
+				data.WriteString(dataLine)
+			}
+
+			server.data = data.String()
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestEmailSink_Send(t *testing.T) {
+	message := &Message{
+		Subject: "[FAILURE] Disk opened",
+		Body:    "Something is wrong\nwith the disk",
+		Event:   eygo.AlertOpened,
+		Alert:   &eygo.Alert{ID: "1", Name: "Disk"},
+	}
+
+	t.Run("with recipients", func(t *testing.T) {
+		server := newSMTPStandIn(t)
+		defer server.listener.Close()
+
+		sink := NewEmailSink(server.listener.Addr().String(), "eygo@example.com", "ops@example.com", "dev@example.com")
+
+		err := sink.Send(message)
+		<-server.done
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it delivers to every recipient", func(t *testing.T) {
+			if server.from != "eygo@example.com" {
+				t.Errorf("Unexpected sender: %s", server.from)
+			}
+
+			if len(server.recipients) != 2 {
+				t.Errorf("Expected 2 recipients, got %v", server.recipients)
+			}
+		})
+
+		t.Run("it sends the subject and body", func(t *testing.T) {
+			if !strings.Contains(server.data, "Subject: [FAILURE] Disk opened\r\n") {
+				t.Errorf("Expected the subject header, got %s", server.data)
+			}
+
+			if !strings.Contains(server.data, "Something is wrong\r\nwith the disk") {
+				t.Errorf("Expected the body, got %s", server.data)
+			}
+		})
+	})
+
+	t.Run("without recipients", func(t *testing.T) {
+		t.Run("it returns an error", func(t *testing.T) {
+			if err := NewEmailSink("127.0.0.1:1", "eygo@example.com").Send(message); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
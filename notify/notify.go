@@ -0,0 +1,391 @@
+// Package notify provides a Notifier that routes Engine Yard alerts to
+// pluggable Sinks such as webhooks, email, and local commands.
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/ess/eygo"
+)
+
+const (
+	// DefaultSubject is the template used for message subjects when a Rule
+	// doesn't specify one.
+	DefaultSubject = `[{{.Alert.Severity}}] {{.Alert.Name}} {{.Event}}`
+
+	// DefaultBody is the template used for message bodies when a Rule doesn't
+	// specify one.
+	DefaultBody = `{{.Alert.Message}}
+{{if .Alert.Description}}
+{{.Alert.Description}}
+{{end}}
+Severity: {{.Alert.Severity}}
+Started: {{.Alert.StartedAt}}
+Resource: {{.Alert.ResourceURL}}
+Environment: {{.Alert.EnvironmentURL}}
+`
+)
+
+// Sink is an interface that describes a destination for notification
+// messages.
+type Sink interface {
+	Send(*Message) error
+}
+
+// Message is a data structure that describes a single notification, as
+// rendered from an AlertEvent.
+type Message struct {
+	Subject string
+	Body    string
+	Event   eygo.AlertEventType
+	Alert   *eygo.Alert
+}
+
+// Match is a data structure that describes the alerts to which a Rule or a
+// Silence applies. Empty criteria match every alert.
+type Match struct {
+	// Severities is a list of alert severities, compared without regard to
+	// case.
+	Severities []string
+
+	// Environments is a list of environment IDs or URLs.
+	Environments []string
+
+	// Names is a list of alert names, in which "*" matches any run of
+	// characters and "?" matches any single character.
+	Names []string
+}
+
+// Matches returns true if the given Alert satisfies the criteria, and false
+// otherwise.
+func (match Match) Matches(alert *eygo.Alert) bool {
+	if len(match.Severities) > 0 && !anyOf(match.Severities, func(severity string) bool {
+		return strings.EqualFold(severity, alert.Severity)
+	}) {
+		return false
+	}
+
+	if len(match.Environments) > 0 && !anyOf(match.Environments, func(environment string) bool {
+		return environment == alert.EnvironmentURL ||
+			strings.HasSuffix(alert.EnvironmentURL, "/environments/"+environment)
+	}) {
+		return false
+	}
+
+	if len(match.Names) > 0 && !anyOf(match.Names, func(name string) bool {
+		return wildcard(name, alert.Name)
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// Rule is a data structure that routes the alerts that it matches to the
+// named Sinks, using the given templates to build the message.
+type Rule struct {
+	Match
+
+	// Sinks is a list of the names of the sinks that receive messages.
+	Sinks []string
+
+	// Subject is a text/template for the message subject.
+	Subject string
+
+	// Body is a text/template for the message body.
+	Body string
+}
+
+// Silence is a data structure that suppresses notifications for the alerts
+// that it matches while the current time is between Start and End.
+type Silence struct {
+	Match
+
+	Start time.Time
+	End   time.Time
+}
+
+// Active returns true if the given time falls within the silence window, and
+// false otherwise.
+func (silence *Silence) Active(now time.Time) bool {
+	return !now.Before(silence.Start) && now.Before(silence.End)
+}
+
+// Notifier routes alert events to Sinks according to its Rules.
+type Notifier struct {
+	// Sinks is the set of known sinks, indexed by name.
+	Sinks map[string]Sink
+
+	// Rules is the list of routing rules. Every matching rule is applied.
+	Rules []*Rule
+
+	// Silences is the list of silence windows.
+	Silences []*Silence
+
+	// RateLimit is the maximum number of messages that a single sink will be
+	// sent in any RatePeriod. Zero means no limit.
+	RateLimit int
+
+	// RatePeriod is the window over which RateLimit applies.
+	RatePeriod time.Duration
+
+	mutex sync.Mutex
+	sent  map[string][]time.Time
+	now   func() time.Time
+}
+
+// NewNotifier returns a Notifier with no sinks, rules, or silences.
+func NewNotifier() *Notifier {
+	return &Notifier{
+		Sinks:    make(map[string]Sink),
+		Rules:    make([]*Rule, 0),
+		Silences: make([]*Silence, 0),
+	}
+}
+
+// AddSink registers the given Sink under the given name.
+func (notifier *Notifier) AddSink(name string, sink Sink) {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	if notifier.Sinks == nil {
+		notifier.Sinks = make(map[string]Sink)
+	}
+
+	notifier.Sinks[name] = sink
+}
+
+// AddRule appends the given Rule to the Notifier's rules.
+func (notifier *Notifier) AddRule(rule *Rule) {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	notifier.Rules = append(notifier.Rules, rule)
+}
+
+// Silence suppresses notifications for the alerts matched by the given Match
+// from now until the given duration has elapsed.
+func (notifier *Notifier) Silence(match Match, duration time.Duration) *Silence {
+	start := notifier.clock()
+	silence := &Silence{Match: match, Start: start, End: start.Add(duration)}
+
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	notifier.Silences = append(notifier.Silences, silence)
+
+	return silence
+}
+
+// Notify renders a message for the given AlertEvent for every matching Rule
+// and sends it to the Rule's sinks. Each sink receives at most one message per
+// event. Events that are silenced, and messages that would exceed the rate
+// limit, are dropped. If any sink fails, an error
+// that describes every failure is returned.
+func (notifier *Notifier) Notify(event *eygo.AlertEvent) error {
+	if event == nil || event.Alert == nil {
+		return fmt.Errorf("No valid alert event given")
+	}
+
+	now := notifier.clock()
+	rules, silences := notifier.snapshot()
+
+	for _, silence := range silences {
+		if silence.Active(now) && silence.Matches(event.Alert) {
+			return nil
+		}
+	}
+
+	failures := make([]string, 0)
+	delivered := make(map[string]bool)
+
+	for _, rule := range rules {
+		if !rule.Matches(event.Alert) {
+			continue
+		}
+
+		message, err := render(rule, event)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+
+		for _, name := range rule.Sinks {
+			if delivered[name] {
+				continue
+			}
+			delivered[name] = true
+
+			sink := notifier.sink(name)
+			if sink == nil {
+				failures = append(failures, fmt.Sprintf("%s: unknown sink", name))
+				continue
+			}
+
+			if !notifier.allow(name, now) {
+				continue
+			}
+
+			if err := sink.Send(message); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %s", name, err))
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("Couldn't deliver notifications: %s", strings.Join(failures, "; "))
+	}
+
+	return nil
+}
+
+// Listen calls Notify for every AlertEvent received on the given channel
+// until the channel is closed. Delivery errors are passed to the given
+// function if it isn't nil.
+func (notifier *Notifier) Listen(events <-chan *eygo.AlertEvent, errors func(error)) {
+	for event := range events {
+		if err := notifier.Notify(event); err != nil && errors != nil {
+			errors(err)
+		}
+	}
+}
+
+// snapshot returns copies of the Notifier's rules and silences, so that they
+// can be read while other goroutines add to them.
+func (notifier *Notifier) snapshot() ([]*Rule, []*Silence) {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	rules := append([]*Rule{}, notifier.Rules...)
+	silences := append([]*Silence{}, notifier.Silences...)
+
+	return rules, silences
+}
+
+func (notifier *Notifier) sink(name string) Sink {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	return notifier.Sinks[name]
+}
+
+func (notifier *Notifier) allow(name string, now time.Time) bool {
+	if notifier.RateLimit <= 0 {
+		return true
+	}
+
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	if notifier.sent == nil {
+		notifier.sent = make(map[string][]time.Time)
+	}
+
+	recent := make([]time.Time, 0)
+	for _, sent := range notifier.sent[name] {
+		if now.Sub(sent) < notifier.RatePeriod {
+			recent = append(recent, sent)
+		}
+	}
+
+	if len(recent) >= notifier.RateLimit {
+		notifier.sent[name] = recent
+		return false
+	}
+
+	notifier.sent[name] = append(recent, now)
+
+	return true
+}
+
+func (notifier *Notifier) clock() time.Time {
+	if notifier.now != nil {
+		return notifier.now()
+	}
+
+	return time.Now()
+}
+
+func render(rule *Rule, event *eygo.AlertEvent) (*Message, error) {
+	data := struct {
+		Event eygo.AlertEventType
+		Alert *eygo.Alert
+	}{Event: event.Type, Alert: event.Alert}
+
+	subject, err := execute(rule.Subject, DefaultSubject, data)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := execute(rule.Body, DefaultBody, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		Subject: strings.TrimSpace(subject),
+		Body:    body,
+		Event:   event.Type,
+		Alert:   event.Alert,
+	}, nil
+}
+
+func execute(text string, fallback string, data interface{}) (string, error) {
+	if len(text) == 0 {
+		text = fallback
+	}
+
+	tmpl, err := template.New("message").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var output bytes.Buffer
+	if err := tmpl.Execute(&output, data); err != nil {
+		return "", err
+	}
+
+	return output.String(), nil
+}
+
+func wildcard(pattern string, value string) bool {
+	expression := regexp.QuoteMeta(pattern)
+	expression = strings.Replace(expression, `\*`, ".*", -1)
+	expression = strings.Replace(expression, `\?`, ".", -1)
+
+	matched, err := regexp.MatchString("^"+expression+"$", value)
+
+	return err == nil && matched
+}
+
+func anyOf(values []string, check func(string) bool) bool {
+	for _, value := range values {
+		if check(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
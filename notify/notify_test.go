@@ -0,0 +1,310 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ess/eygo"
+)
+
+type recordingSink struct {
+	messages []*Message
+	err      error
+}
+
+func (sink *recordingSink) Send(message *Message) error {
+	sink.messages = append(sink.messages, message)
+	return sink.err
+}
+
+func testEvent(id string, severity string, name string, environment string) *eygo.AlertEvent {
+	return &eygo.AlertEvent{
+		Type: eygo.AlertOpened,
+		Alert: &eygo.Alert{
+			ID:             id,
+			Severity:       severity,
+			Name:           name,
+			Message:        "Something is wrong",
+			EnvironmentURL: "https://api.engineyard.com/environments/" + environment,
+		},
+	}
+}
+
+func TestMatch_Matches(t *testing.T) {
+	alert := testEvent("1", "FAILURE", "Disk Usage /data", "42").Alert
+
+	t.Run("an empty match matches everything", func(t *testing.T) {
+		if !(Match{}).Matches(alert) {
+			t.Errorf("Expected a match")
+		}
+	})
+
+	t.Run("severities are compared without regard to case", func(t *testing.T) {
+		if !(Match{Severities: []string{"failure"}}).Matches(alert) {
+			t.Errorf("Expected a match")
+		}
+
+		if (Match{Severities: []string{"warning"}}).Matches(alert) {
+			t.Errorf("Expected no match")
+		}
+	})
+
+	t.Run("environments match by ID or URL", func(t *testing.T) {
+		if !(Match{Environments: []string{"42"}}).Matches(alert) {
+			t.Errorf("Expected a match by ID")
+		}
+
+		if !(Match{Environments: []string{alert.EnvironmentURL}}).Matches(alert) {
+			t.Errorf("Expected a match by URL")
+		}
+
+		if (Match{Environments: []string{"2"}}).Matches(alert) {
+			t.Errorf("Expected no match")
+		}
+	})
+
+	t.Run("names allow wildcards", func(t *testing.T) {
+		if !(Match{Names: []string{"Disk Usage*"}}).Matches(alert) {
+			t.Errorf("Expected a match")
+		}
+
+		if (Match{Names: []string{"Load*"}}).Matches(alert) {
+			t.Errorf("Expected no match")
+		}
+	})
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	t.Run("routing", func(t *testing.T) {
+		pager := &recordingSink{}
+		chat := &recordingSink{}
+
+		notifier := NewNotifier()
+		notifier.AddSink("pager", pager)
+		notifier.AddSink("chat", chat)
+		notifier.AddRule(&Rule{Match: Match{Severities: []string{"FAILURE"}}, Sinks: []string{"pager", "chat"}})
+		notifier.AddRule(&Rule{Match: Match{Environments: []string{"42"}}, Sinks: []string{"chat"}})
+
+		notifier.Notify(testEvent("1", "FAILURE", "Disk", "1"))
+		notifier.Notify(testEvent("2", "WARNING", "Load", "42"))
+		notifier.Notify(testEvent("3", "WARNING", "Load", "1"))
+
+		t.Run("it sends matching alerts to the rule's sinks", func(t *testing.T) {
+			if len(pager.messages) != 1 || pager.messages[0].Alert.ID != "1" {
+				t.Errorf("Expected the pager to receive alert 1")
+			}
+
+			if len(chat.messages) != 2 {
+				t.Errorf("Expected chat to receive 2 messages, got %d", len(chat.messages))
+			}
+		})
+	})
+
+	t.Run("a sink receives one message per event", func(t *testing.T) {
+		chat := &recordingSink{}
+
+		notifier := NewNotifier()
+		notifier.AddSink("chat", chat)
+		notifier.AddRule(&Rule{Sinks: []string{"chat"}})
+		notifier.AddRule(&Rule{Sinks: []string{"chat"}})
+
+		notifier.Notify(testEvent("1", "FAILURE", "Disk", "1"))
+
+		if len(chat.messages) != 1 {
+			t.Errorf("Expected 1 message, got %d", len(chat.messages))
+		}
+	})
+
+	t.Run("templates", func(t *testing.T) {
+		chat := &recordingSink{}
+
+		notifier := NewNotifier()
+		notifier.AddSink("chat", chat)
+		notifier.AddRule(
+			&Rule{
+				Sinks:   []string{"chat"},
+				Subject: "{{.Event}}: {{.Alert.Name}}",
+				Body:    "{{.Alert.Severity}} - {{.Alert.Message}}",
+			},
+		)
+
+		notifier.Notify(testEvent("1", "FAILURE", "Disk", "1"))
+
+		t.Run("it renders the subject and body from the alert", func(t *testing.T) {
+			message := chat.messages[0]
+
+			if message.Subject != "opened: Disk" {
+				t.Errorf("Unexpected subject: %s", message.Subject)
+			}
+
+			if message.Body != "FAILURE - Something is wrong" {
+				t.Errorf("Unexpected body: %s", message.Body)
+			}
+		})
+
+		t.Run("it falls back to the default templates", func(t *testing.T) {
+			defaults := &recordingSink{}
+			notifier := NewNotifier()
+			notifier.AddSink("defaults", defaults)
+			notifier.AddRule(&Rule{Sinks: []string{"defaults"}})
+
+			notifier.Notify(testEvent("1", "FAILURE", "Disk", "1"))
+
+			message := defaults.messages[0]
+
+			if message.Subject != "[FAILURE] Disk opened" {
+				t.Errorf("Unexpected subject: %s", message.Subject)
+			}
+
+			if !strings.Contains(message.Body, "Something is wrong") {
+				t.Errorf("Unexpected body: %s", message.Body)
+			}
+		})
+
+		t.Run("it reports broken templates", func(t *testing.T) {
+			notifier := NewNotifier()
+			notifier.AddSink("chat", &recordingSink{})
+			notifier.AddRule(&Rule{Sinks: []string{"chat"}, Subject: "{{.Nope"})
+
+			if err := notifier.Notify(testEvent("1", "FAILURE", "Disk", "1")); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("silences", func(t *testing.T) {
+		chat := &recordingSink{}
+		now := time.Date(2018, 6, 15, 10, 0, 0, 0, time.UTC)
+
+		notifier := NewNotifier()
+		notifier.now = func() time.Time { return now }
+		notifier.AddSink("chat", chat)
+		notifier.AddRule(&Rule{Sinks: []string{"chat"}})
+		notifier.Silence(Match{Environments: []string{"42"}}, time.Hour)
+
+		notifier.Notify(testEvent("1", "FAILURE", "Disk", "42"))
+		notifier.Notify(testEvent("2", "FAILURE", "Disk", "1"))
+
+		t.Run("it drops silenced alerts during the window", func(t *testing.T) {
+			if len(chat.messages) != 1 || chat.messages[0].Alert.ID != "2" {
+				t.Errorf("Expected only alert 2 to be delivered")
+			}
+		})
+
+		t.Run("it delivers them once the window ends", func(t *testing.T) {
+			now = now.Add(2 * time.Hour)
+			notifier.Notify(testEvent("3", "FAILURE", "Disk", "42"))
+
+			if len(chat.messages) != 2 {
+				t.Errorf("Expected 2 messages, got %d", len(chat.messages))
+			}
+		})
+	})
+
+	t.Run("rate limiting", func(t *testing.T) {
+		chat := &recordingSink{}
+		now := time.Date(2018, 6, 15, 10, 0, 0, 0, time.UTC)
+
+		notifier := NewNotifier()
+		notifier.now = func() time.Time { return now }
+		notifier.RateLimit = 2
+		notifier.RatePeriod = time.Minute
+		notifier.AddSink("chat", chat)
+		notifier.AddRule(&Rule{Sinks: []string{"chat"}})
+
+		for i := 0; i < 5; i++ {
+			notifier.Notify(testEvent(fmt.Sprint(i), "FAILURE", "Disk", "1"))
+		}
+
+		t.Run("it drops messages beyond the limit", func(t *testing.T) {
+			if len(chat.messages) != 2 {
+				t.Errorf("Expected 2 messages, got %d", len(chat.messages))
+			}
+		})
+
+		t.Run("it allows messages again after the period", func(t *testing.T) {
+			now = now.Add(time.Minute)
+			notifier.Notify(testEvent("6", "FAILURE", "Disk", "1"))
+
+			if len(chat.messages) != 3 {
+				t.Errorf("Expected 3 messages, got %d", len(chat.messages))
+			}
+		})
+	})
+
+	t.Run("delivery failures", func(t *testing.T) {
+		notifier := NewNotifier()
+		notifier.AddSink("broken", &recordingSink{err: fmt.Errorf("Oh no!")})
+		notifier.AddRule(&Rule{Sinks: []string{"broken", "missing"}})
+
+		err := notifier.Notify(testEvent("1", "FAILURE", "Disk", "1"))
+
+		t.Run("it reports every failure", func(t *testing.T) {
+			if err == nil {
+				t.Fatalf("Expected an error")
+			}
+
+			if !strings.Contains(err.Error(), "broken") || !strings.Contains(err.Error(), "missing") {
+				t.Errorf("Unexpected error: %s", err)
+			}
+		})
+	})
+}
+
+func TestNotifier_Listen(t *testing.T) {
+	chat := &recordingSink{err: fmt.Errorf("Oh no!")}
+	notifier := NewNotifier()
+	notifier.AddSink("chat", chat)
+	notifier.AddRule(&Rule{Sinks: []string{"chat"}})
+
+	events := make(chan *eygo.AlertEvent, 2)
+	events <- testEvent("1", "FAILURE", "Disk", "1")
+	events <- testEvent("2", "FAILURE", "Disk", "1")
+	close(events)
+
+	errors := 0
+	notifier.Listen(events, func(error) { errors = errors + 1 })
+
+	t.Run("it notifies for every event", func(t *testing.T) {
+		if len(chat.messages) != 2 {
+			t.Errorf("Expected 2 messages, got %d", len(chat.messages))
+		}
+	})
+
+	t.Run("it reports delivery errors", func(t *testing.T) {
+		if errors != 2 {
+			t.Errorf("Expected 2 errors, got %d", errors)
+		}
+	})
+}
+
+func TestNotifier_Concurrency(t *testing.T) {
+	chat := &recordingSink{}
+	notifier := NewNotifier()
+	notifier.AddSink("chat", chat)
+
+	events := make(chan *eygo.AlertEvent)
+	done := make(chan bool)
+
+	go func() {
+		notifier.Listen(events, nil)
+		close(done)
+	}()
+
+	t.Run("it allows rules and silences to be added while listening", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			notifier.AddRule(&Rule{Sinks: []string{"chat"}})
+			notifier.Silence(Match{Names: []string{"Other"}}, time.Minute)
+			events <- testEvent("1", "FAILURE", "Disk", "1")
+		}
+
+		close(events)
+		<-done
+
+		if len(chat.messages) != 10 {
+			t.Errorf("Expected 10 messages, got %d", len(chat.messages))
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ess/eygo"
+)
+
+// WebhookSink is a Sink that POSTs messages as JSON to a URL.
+type WebhookSink struct {
+	URL     string
+	Headers map[string]string
+	Client  *http.Client
+}
+
+// NewWebhookSink returns a WebhookSink that posts to the given URL.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		URL:     url,
+		Headers: make(map[string]string),
+		Client:  &http.Client{Timeout: 20 * time.Second},
+	}
+}
+
+type webhookPayload struct {
+	Subject string              `json:"subject"`
+	Body    string              `json:"body"`
+	Event   eygo.AlertEventType `json:"event,omitempty"`
+	Alert   *eygo.Alert         `json:"alert,omitempty"`
+}
+
+// Send posts the given Message to the webhook. If the request fails or the
+// webhook responds with a non-2xx status, an error is returned.
+func (sink *WebhookSink) Send(message *Message) error {
+	body, err := json.Marshal(
+		&webhookPayload{
+			Subject: message.Subject,
+			Body:    message.Body,
+			Event:   message.Event,
+			Alert:   message.Alert,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest("POST", sink.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range sink.Headers {
+		request.Header.Set(key, value)
+	}
+
+	client := sink.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("The webhook returned the following status: %d", response.StatusCode)
+	}
+
+	return nil
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
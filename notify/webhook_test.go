@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ess/eygo"
+)
+
+func TestWebhookSink_Send(t *testing.T) {
+	message := &Message{
+		Subject: "[FAILURE] Disk opened",
+		Body:    "Something is wrong",
+		Event:   eygo.AlertOpened,
+		Alert:   &eygo.Alert{ID: "1", Name: "Disk"},
+	}
+
+	t.Run("when the webhook accepts the message", func(t *testing.T) {
+		var received webhookPayload
+		var token string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token = r.Header.Get("X-Token")
+			json.NewDecoder(r.Body).Decode(&received)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		sink := NewWebhookSink(server.URL)
+		sink.Headers["X-Token"] = "secret"
+
+		err := sink.Send(message)
+
+		t.Run("it posts the message as JSON", func(t *testing.T) {
+			if received.Subject != message.Subject || received.Alert == nil || received.Alert.ID != "1" {
+				t.Errorf("Unexpected payload: %v", received)
+			}
+		})
+
+		t.Run("it sends the configured headers", func(t *testing.T) {
+			if token != "secret" {
+				t.Errorf("Expected the X-Token header to be sent")
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+	})
+
+	t.Run("when the webhook rejects the message", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err := NewWebhookSink(server.URL).Send(message); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
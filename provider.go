@@ -2,6 +2,8 @@ package eygo
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
 )
 
 // Provider is a data structure that models an infrastructure provider on the
@@ -30,6 +32,45 @@ type Credentials struct {
 	PayerAccountID       string `json:"payer_account_id,omitempty"`
 }
 
+// String returns a representation of the credentials in which the secret
+// keys and password are masked. The real values remain available through the
+// fields themselves and are sent to the API as-is.
+func (credentials Credentials) String() string {
+	return fmt.Sprintf(
+		"{InstanceAwsSecretID:%s InstanceAwsSecretKey:%s AwsSecretID:%s AwsSecretKey:%s AwsLogin:%s AwsPass:%s PayerAccountID:%s}",
+		credentials.InstanceAwsSecretID,
+		redact(credentials.InstanceAwsSecretKey),
+		credentials.AwsSecretID,
+		redact(credentials.AwsSecretKey),
+		credentials.AwsLogin,
+		redact(credentials.AwsPass),
+		credentials.PayerAccountID,
+	)
+}
+
+// GoString returns a Go-syntax representation of the credentials in which the
+// secret keys and password are masked.
+func (credentials Credentials) GoString() string {
+	return fmt.Sprintf(
+		"eygo.Credentials{InstanceAwsSecretID:%q, InstanceAwsSecretKey:%q, AwsSecretID:%q, AwsSecretKey:%q, AwsLogin:%q, AwsPass:%q, PayerAccountID:%q}",
+		credentials.InstanceAwsSecretID,
+		redact(credentials.InstanceAwsSecretKey),
+		credentials.AwsSecretID,
+		redact(credentials.AwsSecretKey),
+		credentials.AwsLogin,
+		redact(credentials.AwsPass),
+		credentials.PayerAccountID,
+	)
+}
+
+func redact(secret string) string {
+	if len(secret) == 0 {
+		return ""
+	}
+
+	return maskedValue
+}
+
 // ProviderService is a repository one can use to retrieve Provider records
 // from the API.
 type ProviderService struct {
@@ -53,6 +94,112 @@ func (service *ProviderService) ForAccount(account *Account, params Params) []*P
 	return service.collection("accounts/"+account.ID+"/providers", params)
 }
 
+// Find returns the Provider record identified by the given provider id. If
+// there are errors in retrieving this information, an error is returned as
+// well.
+func (service *ProviderService) Find(id string) (*Provider, error) {
+	return service.unwrap(service.Driver.Get("providers/"+id, nil))
+}
+
+type providerParams struct {
+	Type          string       `json:"type,omitempty"`
+	ProvisionedID string       `json:"provisioned_id,omitempty"`
+	Credentials   *Credentials `json:"credentials,omitempty"`
+}
+
+// Create takes an Account and a Provider, saving the Provider on the upstream
+// API under the given Account. The Provider must have a Type and Credentials.
+// If there are issues along the way, an error is returned. Otherwise, the
+// newly created Provider is returned.
+func (service *ProviderService) Create(account *Account, provider *Provider) (*Provider, error) {
+	if account == nil || len(account.ID) == 0 {
+		return nil, fmt.Errorf("No valid account given")
+	}
+
+	if provider == nil || len(provider.Type) == 0 {
+		return nil, fmt.Errorf("A provider requires a type")
+	}
+
+	if provider.Credentials == nil {
+		return nil, fmt.Errorf("A provider requires credentials")
+	}
+
+	body, err := service.encode(&providerParams{
+		Type:          provider.Type,
+		ProvisionedID: provider.ProvisionedID,
+		Credentials:   provider.Credentials,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Post("accounts/"+account.ID+"/providers", nil, body),
+	)
+}
+
+// UpdateCredentials replaces the Credentials of the given Provider on the
+// upstream API. If there are issues along the way, an error is returned.
+// Otherwise, the updated Provider is returned.
+func (service *ProviderService) UpdateCredentials(provider *Provider, credentials *Credentials) (*Provider, error) {
+	if provider == nil || provider.ID == 0 {
+		return nil, fmt.Errorf("can't update a provider without an ID")
+	}
+
+	if credentials == nil {
+		return nil, fmt.Errorf("No credentials given")
+	}
+
+	body, err := service.encode(&providerParams{Credentials: credentials})
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Put("providers/"+strconv.Itoa(provider.ID), nil, body),
+	)
+}
+
+// Cancel cancels the given Provider on the upstream API. If there are issues
+// along the way, an error is returned.
+func (service *ProviderService) Cancel(provider *Provider) error {
+	if provider == nil || provider.ID == 0 {
+		return fmt.Errorf("No valid provider given")
+	}
+
+	response := service.Driver.Delete("providers/"+strconv.Itoa(provider.ID), Params{})
+	if !response.Okay() {
+		return response.Error
+	}
+
+	return nil
+}
+
+func (service *ProviderService) encode(params *providerParams) ([]byte, error) {
+	wrapper := struct {
+		Provider *providerParams `json:"provider,omitempty"`
+	}{Provider: params}
+
+	return json.Marshal(&wrapper)
+}
+
+func (service *ProviderService) unwrap(response Response) (*Provider, error) {
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		Provider *Provider `json:"provider,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Provider, nil
+}
+
 func (service *ProviderService) collection(path string, params Params) []*Provider {
 	providers := make([]*Provider, 0)
 	response := service.Driver.Get(path, params)
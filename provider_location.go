@@ -63,23 +63,120 @@ func (service *ProviderLocationService) Children(location *ProviderLocation, par
 	)
 }
 
+// LocationNode is a data structure that places a ProviderLocation within the
+// location hierarchy of its Provider.
+type LocationNode struct {
+	Location *ProviderLocation
+	Children []*LocationNode
+}
+
+// Walk calls the given function for the node and each of its descendants,
+// parents before children, along with the depth of each node (zero for the
+// receiver).
+func (node *LocationNode) Walk(fn func(node *LocationNode, depth int)) {
+	node.walk(fn, 0)
+}
+
+func (node *LocationNode) walk(fn func(node *LocationNode, depth int), depth int) {
+	fn(node, depth)
+
+	for _, child := range node.Children {
+		child.walk(fn, depth+1)
+	}
+}
+
+// Tree returns the full location hierarchy for the given Provider: one node
+// for each of its top-level locations, with their descendants retrieved
+// recursively. Each location appears in the tree at most once. If any of
+// the locations can't be retrieved, an error is returned.
+func (service *ProviderLocationService) Tree(provider *Provider) ([]*LocationNode, error) {
+	tree := make([]*LocationNode, 0)
+
+	locations, err := service.fetch(fmt.Sprintf("providers/%d/locations", provider.ID), nil)
+	if err != nil {
+		return tree, err
+	}
+
+	roots := make([]*ProviderLocation, 0)
+
+	for _, location := range locations {
+		if len(location.ParentURL) == 0 {
+			roots = append(roots, location)
+		}
+	}
+
+	if len(roots) == 0 {
+		roots = locations
+	}
+
+	seen := make(map[string]bool)
+
+	for _, root := range roots {
+		node, err := service.branch(root, seen)
+		if err != nil {
+			return tree, err
+		}
+
+		if node != nil {
+			tree = append(tree, node)
+		}
+	}
+
+	return tree, nil
+}
+
+func (service *ProviderLocationService) branch(location *ProviderLocation, seen map[string]bool) (*LocationNode, error) {
+	if seen[location.ID] {
+		return nil, nil
+	}
+
+	seen[location.ID] = true
+	node := &LocationNode{Location: location, Children: make([]*LocationNode, 0)}
+
+	children, err := service.fetch("provider-locations/"+location.ID+"/provider-locations", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		branch, err := service.branch(child, seen)
+		if err != nil {
+			return nil, err
+		}
+
+		if branch != nil {
+			node.Children = append(node.Children, branch)
+		}
+	}
+
+	return node, nil
+}
+
 func (service *ProviderLocationService) collection(path string, params Params) []*ProviderLocation {
+	locations, _ := service.fetch(path, params)
+
+	return locations
+}
+
+func (service *ProviderLocationService) fetch(path string, params Params) ([]*ProviderLocation, error) {
 	locations := make([]*ProviderLocation, 0)
 	response := service.Driver.Get(path, params)
 
-	if response.Okay() {
-		for _, page := range response.Pages {
-			wrapper := struct {
-				ProviderLocations []*ProviderLocation `json:"provider_locations,omitempty"`
-			}{}
+	if !response.Okay() {
+		return locations, response.Error
+	}
 
-			if err := json.Unmarshal(page, &wrapper); err == nil {
-				locations = append(locations, wrapper.ProviderLocations...)
-			}
+	for _, page := range response.Pages {
+		wrapper := struct {
+			ProviderLocations []*ProviderLocation `json:"provider_locations,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(page, &wrapper); err == nil {
+			locations = append(locations, wrapper.ProviderLocations...)
 		}
 	}
 
-	return locations
+	return locations, nil
 }
 
 /*
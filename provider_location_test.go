@@ -2,7 +2,8 @@ package eygo
 
 import (
 	"encoding/json"
-	//"fmt"
+	"fmt"
+	"reflect"
 	"strconv"
 	"testing"
 )
@@ -124,6 +125,64 @@ func TestProviderLocationService_Children(t *testing.T) {
 
 }
 
+func TestProviderLocationService_Tree(t *testing.T) {
+	provider := &Provider{ID: 1}
+	driver := NewMockDriver()
+	service := NewProviderLocationService(driver)
+
+	east := &ProviderLocation{ID: "1", LocationID: "us-east-1"}
+	west := &ProviderLocation{ID: "2", LocationID: "us-west-2"}
+	eastA := &ProviderLocation{ID: "3", LocationID: "us-east-1a", ParentURL: "https://api.engineyard.com/provider-locations/1"}
+	eastB := &ProviderLocation{ID: "4", LocationID: "us-east-1b", ParentURL: "https://api.engineyard.com/provider-locations/1"}
+	westA := &ProviderLocation{ID: "5", LocationID: "us-west-2a", ParentURL: "https://api.engineyard.com/provider-locations/2"}
+
+	stubProviderProviderLocations(driver, provider, east, west, eastA)
+	stubChildren(driver, east, eastA, eastB)
+	stubChildren(driver, west, westA, east)
+	stubChildren(driver, eastA)
+	stubChildren(driver, eastB)
+	stubChildren(driver, westA)
+
+	tree, err := service.Tree(provider)
+
+	t.Run("it returns no error", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("it has a node for each top-level location", func(t *testing.T) {
+		if len(tree) != 2 || tree[0].Location.ID != "1" || tree[1].Location.ID != "2" {
+			t.Fatalf("Unexpected roots: %v", tree)
+		}
+	})
+
+	t.Run("it includes the descendants of each location", func(t *testing.T) {
+		visited := make([]string, 0)
+
+		for _, root := range tree {
+			root.Walk(func(node *LocationNode, depth int) {
+				visited = append(visited, fmt.Sprintf("%d:%s", depth, node.Location.LocationID))
+			})
+		}
+
+		expected := []string{"0:us-east-1", "1:us-east-1a", "1:us-east-1b", "0:us-west-2", "1:us-west-2a"}
+		if !reflect.DeepEqual(visited, expected) {
+			t.Errorf("Expected %v, got %v", expected, visited)
+		}
+	})
+
+	t.Run("when a location's children can't be retrieved", func(t *testing.T) {
+		driver.Reset()
+		stubProviderProviderLocations(driver, provider, east)
+		driver.AddResponse("get", "provider-locations/1/provider-locations", Response{Error: fmt.Errorf("Oh no!")})
+
+		if _, err := service.Tree(provider); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
 func stubProviderProviderLocations(driver *MockDriver, provider *Provider, providerLocations ...*ProviderLocation) {
 	pages := make([][]byte, 0)
 
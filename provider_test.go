@@ -2,7 +2,10 @@ package eygo
 
 import (
 	"encoding/json"
-	//"fmt"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -122,6 +125,225 @@ func TestProviderService_ForAccount(t *testing.T) {
 
 }
 
+func TestProviderService_Find(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewProviderService(driver)
+	stubProvider(driver, &Provider{ID: 1, Type: "amazon"})
+
+	t.Run("for a known provider", func(t *testing.T) {
+		result, err := service.Find("1")
+
+		t.Run("it is the requested provider", func(t *testing.T) {
+			if result == nil || result.ID != 1 {
+				t.Errorf("Expected provider 1, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("for an unknown provider", func(t *testing.T) {
+		result, err := service.Find("2")
+
+		t.Run("it returns no provider", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no provider, got provider %d", result.ID)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestProviderService_Create(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewProviderService(driver)
+	account := &Account{ID: "1"}
+	credentials := &Credentials{AwsSecretID: "AKIA123", AwsSecretKey: "supersecret"}
+
+	t.Run("without a type", func(t *testing.T) {
+		if _, err := service.Create(account, &Provider{Credentials: credentials}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("without credentials", func(t *testing.T) {
+		if _, err := service.Create(account, &Provider{Type: "amazon"}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with a valid provider", func(t *testing.T) {
+		driver.AddResponse(
+			"post",
+			"accounts/1/providers",
+			Response{Pages: [][]byte{[]byte(`{"provider": {"id": 3, "type": "amazon"}}`)}},
+		)
+
+		result, err := service.Create(account, &Provider{Type: "amazon", Credentials: credentials})
+
+		t.Run("it sends the real credentials", func(t *testing.T) {
+			bodies := driver.Bodies("post")
+			expected := `{"provider":{"type":"amazon","credentials":{"aws_secret_id":"AKIA123","aws_secret_key":"supersecret"}}}`
+
+			if len(bodies) != 1 || string(bodies[0]) != expected {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns the new provider", func(t *testing.T) {
+			if result == nil || result.ID != 3 {
+				t.Errorf("Expected provider 3, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestProviderService_UpdateCredentials(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewProviderService(driver)
+	credentials := &Credentials{AwsSecretID: "AKIA456", AwsSecretKey: "newsecret"}
+
+	t.Run("without a provider ID", func(t *testing.T) {
+		if _, err := service.UpdateCredentials(&Provider{}, credentials); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("without credentials", func(t *testing.T) {
+		if _, err := service.UpdateCredentials(&Provider{ID: 3}, nil); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with new credentials", func(t *testing.T) {
+		driver.AddResponse(
+			"put",
+			"providers/3",
+			Response{Pages: [][]byte{[]byte(`{"provider": {"id": 3, "credentials": {"aws_secret_id": "AKIA456"}}}`)}},
+		)
+
+		result, err := service.UpdateCredentials(&Provider{ID: 3}, credentials)
+
+		t.Run("it sends only the credentials", func(t *testing.T) {
+			bodies := driver.Bodies("put")
+			expected := `{"provider":{"credentials":{"aws_secret_id":"AKIA456","aws_secret_key":"newsecret"}}}`
+
+			if len(bodies) != 1 || string(bodies[0]) != expected {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns the updated provider", func(t *testing.T) {
+			if result == nil || result.Credentials == nil || result.Credentials.AwsSecretID != "AKIA456" {
+				t.Errorf("Expected the updated provider, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestProviderService_Cancel(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewProviderService(driver)
+
+	t.Run("when the API accepts the cancellation", func(t *testing.T) {
+		driver.AddResponse("delete", "providers/3", Response{})
+
+		if err := service.Cancel(&Provider{ID: 3}); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("when the API rejects the cancellation", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "providers/3", Response{Error: fmt.Errorf("Oh no!")})
+
+		if err := service.Cancel(&Provider{ID: 3}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestCredentials_redaction(t *testing.T) {
+	encoded := `{"provider": {"id": 3, "credentials": {"aws_secret_id": "AKIA123", "aws_secret_key": "supersecret", "instance_aws_secret_key": "instancesecret", "aws_pass": "hunter2"}}}`
+
+	wrapper := struct {
+		Provider *Provider `json:"provider"`
+	}{}
+
+	if err := json.Unmarshal([]byte(encoded), &wrapper); err != nil {
+		t.Fatalf("Expected the provider to decode, got %s", err)
+	}
+
+	provider := wrapper.Provider
+
+	var logged strings.Builder
+	logger := log.New(&logged, "", 0)
+	logger.Printf("%v %+v", provider, provider.Credentials)
+
+	formats := map[string]string{
+		"%v":             fmt.Sprintf("%v", provider),
+		"%+v":            fmt.Sprintf("%+v", provider),
+		"%#v":            fmt.Sprintf("%#v", provider.Credentials),
+		"%s":             fmt.Sprintf("%s", provider.Credentials),
+		"a value %+v":    fmt.Sprintf("%+v", *provider.Credentials),
+		"a value %#v":    fmt.Sprintf("%#v", *provider.Credentials),
+		"log":            logged.String(),
+		"fmt.Sprint":     fmt.Sprint(provider.Credentials),
+		"a nested value": fmt.Sprintf("%+v", struct{ Credentials Credentials }{*provider.Credentials}),
+	}
+
+	for format, output := range formats {
+		t.Run("it redacts secrets when formatted with "+format, func(t *testing.T) {
+			for _, secret := range []string{"supersecret", "instancesecret", "hunter2"} {
+				if strings.Contains(output, secret) {
+					t.Errorf("Expected %s to be redacted, got %s", secret, output)
+				}
+			}
+		})
+	}
+
+	t.Run("it keeps identifiers visible", func(t *testing.T) {
+		if !strings.Contains(provider.Credentials.String(), "AKIA123") {
+			t.Errorf("Expected the secret ID to be visible")
+		}
+	})
+
+	t.Run("it keeps the real values available", func(t *testing.T) {
+		if provider.Credentials.AwsSecretKey != "supersecret" || provider.Credentials.AwsPass != "hunter2" {
+			t.Errorf("Expected the real values")
+		}
+	})
+
+	t.Run("it marshals the real values", func(t *testing.T) {
+		encoded, _ := json.Marshal(provider)
+
+		if !strings.Contains(string(encoded), "supersecret") {
+			t.Errorf("Expected the real value in %s", encoded)
+		}
+	})
+}
+
 func stubProviders(driver *MockDriver, providers ...*Provider) {
 	pages := make([][]byte, 0)
 
@@ -147,3 +369,16 @@ func stubAccountProviders(driver *MockDriver, account *Account, providers ...*Pr
 		driver.AddResponse("get", "accounts/"+account.ID+"/providers", Response{Pages: pages})
 	}
 }
+
+func stubProvider(driver *MockDriver, provider *Provider) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		Provider *Provider `json:"provider,omitempty"`
+	}{Provider: provider}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "providers/"+strconv.Itoa(provider.ID), Response{Pages: pages})
+	}
+}
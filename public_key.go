@@ -0,0 +1,196 @@
+package eygo
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// PublicKey is a data structure that models an OpenSSH public key as it
+// appears in an authorized_keys file or in the PublicKey of a KeyPair.
+type PublicKey struct {
+	Type    string
+	Bits    int
+	Comment string
+	Blob    []byte
+}
+
+var ecdsaCurves = map[string]struct {
+	name string
+	bits int
+}{
+	"ecdsa-sha2-nistp256": {name: "nistp256", bits: 256},
+	"ecdsa-sha2-nistp384": {name: "nistp384", bits: 384},
+	"ecdsa-sha2-nistp521": {name: "nistp521", bits: 521},
+}
+
+// ParsePublicKey takes a public key in the OpenSSH authorized_keys format
+// ("type base64-blob [comment]") and returns the PublicKey that it describes.
+// RSA, ECDSA, and Ed25519 keys are supported. If the key is malformed or of
+// an unsupported type, an error is returned.
+func ParsePublicKey(text string) (*PublicKey, error) {
+	fields := strings.Fields(strings.TrimSpace(text))
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("Public key must contain a type and a key")
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("Public key is not valid base64: %s", err)
+	}
+
+	key := &PublicKey{
+		Type:    fields[0],
+		Comment: strings.Join(fields[2:], " "),
+		Blob:    blob,
+	}
+
+	reader := bytes.NewReader(blob)
+
+	embedded, err := readSSHString(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if string(embedded) != key.Type {
+		return nil, fmt.Errorf(
+			"Public key type %s doesn't match its encoded type %s",
+			key.Type,
+			embedded,
+		)
+	}
+
+	switch {
+	case key.Type == "ssh-rsa":
+		if _, err := readSSHString(reader); err != nil {
+			return nil, err
+		}
+
+		modulus, err := readSSHString(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		key.Bits = new(big.Int).SetBytes(modulus).BitLen()
+
+	case key.Type == "ssh-ed25519":
+		point, err := readSSHString(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(point) != 32 {
+			return nil, fmt.Errorf("Ed25519 public key must be 32 bytes, got %d", len(point))
+		}
+
+		key.Bits = 256
+
+	case strings.HasPrefix(key.Type, "ecdsa-sha2-"):
+		curve, known := ecdsaCurves[key.Type]
+		if !known {
+			return nil, fmt.Errorf("Unsupported ECDSA curve: %s", key.Type)
+		}
+
+		name, err := readSSHString(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		if string(name) != curve.name {
+			return nil, fmt.Errorf("ECDSA key type %s doesn't match curve %s", key.Type, name)
+		}
+
+		point, err := readSSHString(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(point) != 1+2*((curve.bits+7)/8) || point[0] != 4 {
+			return nil, fmt.Errorf("ECDSA public key has an invalid point")
+		}
+
+		key.Bits = curve.bits
+
+	default:
+		return nil, fmt.Errorf("Unsupported public key type: %s", key.Type)
+	}
+
+	if reader.Len() != 0 {
+		return nil, fmt.Errorf("Public key has trailing data")
+	}
+
+	return key, nil
+}
+
+// MD5Fingerprint returns the legacy colon-separated hex MD5 fingerprint of
+// the key.
+func (key *PublicKey) MD5Fingerprint() string {
+	sum := md5.Sum(key.Blob)
+	parts := make([]string, len(sum))
+
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+
+	return strings.Join(parts, ":")
+}
+
+// SHA256Fingerprint returns the key's SHA256 fingerprint in the format that
+// modern versions of OpenSSH print.
+func (key *PublicKey) SHA256Fingerprint() string {
+	sum := sha256.Sum256(key.Blob)
+
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// MatchesFingerprint returns true if the given fingerprint, in either the MD5
+// or SHA256 format, is the fingerprint of the key.
+func (key *PublicKey) MatchesFingerprint(fingerprint string) bool {
+	fingerprint = strings.TrimSpace(fingerprint)
+
+	if strings.HasPrefix(fingerprint, "SHA256:") {
+		return strings.TrimRight(fingerprint, "=") == key.SHA256Fingerprint()
+	}
+
+	return strings.EqualFold(strings.TrimPrefix(fingerprint, "MD5:"), key.MD5Fingerprint())
+}
+
+func readSSHString(reader *bytes.Reader) ([]byte, error) {
+	var length uint32
+
+	if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
+		return nil, fmt.Errorf("Public key is truncated")
+	}
+
+	if int64(length) > int64(reader.Len()) {
+		return nil, fmt.Errorf("Public key is truncated")
+	}
+
+	value := make([]byte, length)
+	if _, err := reader.Read(value); err != nil && length > 0 {
+		return nil, fmt.Errorf("Public key is truncated")
+	}
+
+	return value, nil
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
@@ -0,0 +1,112 @@
+package eygo
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	testRSAKey     = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDC/QaMk7+7Z0ncmWgopccYqePhDzoFzUkU4NUDndRa0bUvC/usEBP9PH6/M0jBxvItsGBrgt+tqfHaRAaMXE01h15oEtv4QYjwtks1+XR2aO3XCtDXswMaD7Z92UOuRTv+nw5bSfivy0w+gP9yWI4q01Hcv6y376h+jGtoOlcrlKdhEgkTqLcMEQQDkxfFn35mfWTsa2fZDvWgHd27RUzYcpsvOw1FVuN0XSIkAhCGqYOg4CWYTOVDx9n7zeOQ7BPKIDoQF99kvAXu/l0ZmS2NN5a1OHxmAkUY2Hhzn/Ss4CZYaN3ZqSFNe8w0RpplwGZJoOynvv5EvrKsrNloRhaJ rsa@example"
+	testECDSAKey   = "ecdsa-sha2-nistp384 AAAAE2VjZHNhLXNoYTItbmlzdHAzODQAAAAIbmlzdHAzODQAAABhBOfvLZcBNnlMe6EB+YvW2At3SI8VHKjgKU0C4bpSDE0QTT/114ANUCKk+IDMNXCWnb2ztOQGzQAI+fnUeg8WHleyXhitjamAvNIcpdOOzE9jmLDZdzR3n0jc5bfNrvNgTg== ecdsa@example"
+	testEd25519Key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMSdjcVVgFdHqkQuyxaRm8InND8cO54qgfJ0S/jLwEOf ed25519@example"
+)
+
+func TestParsePublicKey(t *testing.T) {
+	cases := []struct {
+		name    string
+		text    string
+		keyType string
+		bits    int
+		comment string
+		md5     string
+		sha256  string
+	}{
+		{
+			"RSA", testRSAKey, "ssh-rsa", 2048, "rsa@example",
+			"0a:b6:40:98:e8:68:de:8c:94:89:94:65:c2:bb:b3:41",
+			"SHA256:wWQ/0gAkKU30ObA9bBtSPPidlMq2Pff2NFwSFVFX7ts",
+		},
+		{
+			"ECDSA", testECDSAKey, "ecdsa-sha2-nistp384", 384, "ecdsa@example",
+			"68:a9:76:bb:79:db:bb:75:a8:fa:d7:a7:f5:90:9d:e6",
+			"SHA256:WDky3GHQoYHtf/gNrr0EPPjMWNtYut+Jv/xP4lbG4Y8",
+		},
+		{
+			"Ed25519", testEd25519Key, "ssh-ed25519", 256, "ed25519@example",
+			"dc:5b:20:db:8e:40:9e:8b:53:35:e5:b1:80:99:f6:63",
+			"SHA256:M6YBcMHXCi/cOcbC9tahhoRI8WpThMPGeQ8DtAwpy/s",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run("for an "+c.name+" key", func(t *testing.T) {
+			key, err := ParsePublicKey(c.text)
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			t.Run("it knows the key type", func(t *testing.T) {
+				if key.Type != c.keyType {
+					t.Errorf("Expected %s, got %s", c.keyType, key.Type)
+				}
+			})
+
+			t.Run("it knows the key size", func(t *testing.T) {
+				if key.Bits != c.bits {
+					t.Errorf("Expected %d bits, got %d", c.bits, key.Bits)
+				}
+			})
+
+			t.Run("it keeps the comment", func(t *testing.T) {
+				if key.Comment != c.comment {
+					t.Errorf("Expected comment %s, got %s", c.comment, key.Comment)
+				}
+			})
+
+			t.Run("it computes the MD5 fingerprint", func(t *testing.T) {
+				if key.MD5Fingerprint() != c.md5 {
+					t.Errorf("Expected %s, got %s", c.md5, key.MD5Fingerprint())
+				}
+			})
+
+			t.Run("it computes the SHA256 fingerprint", func(t *testing.T) {
+				if key.SHA256Fingerprint() != c.sha256 {
+					t.Errorf("Expected %s, got %s", c.sha256, key.SHA256Fingerprint())
+				}
+			})
+
+			t.Run("it matches its own fingerprints", func(t *testing.T) {
+				for _, fingerprint := range []string{c.md5, "MD5:" + strings.ToUpper(c.md5), c.sha256} {
+					if !key.MatchesFingerprint(fingerprint) {
+						t.Errorf("Expected %s to match", fingerprint)
+					}
+				}
+			})
+		})
+	}
+
+	t.Run("it rejects keys with other fingerprints", func(t *testing.T) {
+		key, _ := ParsePublicKey(testRSAKey)
+
+		if key.MatchesFingerprint("SHA256:M6YBcMHXCi/cOcbC9tahhoRI8WpThMPGeQ8DtAwpy/s") {
+			t.Errorf("Expected the Ed25519 fingerprint not to match")
+		}
+	})
+
+	invalid := map[string]string{
+		"empty":          "",
+		"missing a blob": "ssh-rsa",
+		"not base64":     "ssh-rsa !!!!",
+		"mislabeled":     strings.Replace(testEd25519Key, "ssh-ed25519", "ssh-rsa", 1),
+		"truncated":      "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMSdjcVVgFdHqkQu",
+		"unsupported":    "ssh-dss AAAAB3NzaC1kc3M=",
+	}
+
+	for name, text := range invalid {
+		t.Run("it rejects a key that is "+name, func(t *testing.T) {
+			if _, err := ParsePublicKey(text); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
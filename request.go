@@ -3,6 +3,7 @@ package eygo
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/ess/debuggable"
 )
@@ -29,6 +30,12 @@ type Request struct {
 	Resource      interface{} `json:"resource,omitempty"`
 }
 
+// Finished returns true if the request has finished processing, and false
+// otherwise.
+func (request *Request) Finished() bool {
+	return len(request.FinishedAt) > 0
+}
+
 // RequestService is a repository one can use to retrieve Request records from
 // the API.
 type RequestService struct {
@@ -92,6 +99,47 @@ func (service *RequestService) Find(id string) (*Request, error) {
 	return nil, response.Error
 }
 
+// Wait polls the upstream API every interval until the given Request has
+// finished, then returns the finished Request. If the request is
+// unsuccessful, can't be retrieved, or doesn't finish before the timeout
+// elapses, an error is returned. The interval must be positive.
+func (service *RequestService) Wait(request *Request, interval time.Duration, timeout time.Duration) (*Request, error) {
+	if request == nil || len(request.ID) == 0 {
+		return nil, fmt.Errorf("No valid request given")
+	}
+
+	if interval <= 0 {
+		return nil, fmt.Errorf("The polling interval must be positive")
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		current, err := service.Find(request.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if current == nil {
+			return nil, fmt.Errorf("Couldn't find request %s", request.ID)
+		}
+
+		if current.Finished() {
+			if !current.Successful {
+				return current, fmt.Errorf("Request %s failed: %s", current.ID, current.Message)
+			}
+
+			return current, nil
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return current, fmt.Errorf("Timed out waiting for request %s", request.ID)
+		}
+
+		time.Sleep(interval)
+	}
+}
+
 func (service *RequestService) collection(path string, params Params) []*Request {
 	requests := make([]*Request, 0)
 	response := service.Driver.Get(path, params)
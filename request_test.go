@@ -5,6 +5,7 @@ import (
 	//"fmt"
 	"strconv"
 	"testing"
+	"time"
 )
 
 func TestNewRequestService(t *testing.T) {
@@ -268,6 +269,87 @@ func TestRequestService_ForServer(t *testing.T) {
 
 }
 
+func TestRequestService_Wait(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewRequestService(driver)
+	request := &Request{ID: "req1"}
+
+	t.Run("with a non-positive interval", func(t *testing.T) {
+		driver.Reset()
+
+		if _, err := service.Wait(request, 0, time.Second); err == nil {
+			t.Errorf("Expected an error")
+		}
+
+		if len(driver.Requests("get")) != 0 {
+			t.Errorf("Expected no get requests")
+		}
+	})
+
+	t.Run("when the request is missing from the response", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("get", "requests/req1", Response{Pages: [][]byte{[]byte(`{}`)}})
+
+		if _, err := service.Wait(request, time.Millisecond, time.Second); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when the request succeeds", func(t *testing.T) {
+		driver.Reset()
+		stubRequest(driver, &Request{ID: "req1"})
+		stubRequest(driver, &Request{ID: "req1", Successful: true, FinishedAt: "2018-06-15T10:00:00Z"})
+
+		result, err := service.Wait(request, time.Millisecond, time.Second)
+
+		t.Run("it returns the finished request", func(t *testing.T) {
+			if result == nil || !result.Finished() {
+				t.Errorf("Expected a finished request, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when the request fails", func(t *testing.T) {
+		driver.Reset()
+		stubRequest(driver, &Request{ID: "req1", Message: "Nope", FinishedAt: "2018-06-15T10:00:00Z"})
+
+		_, err := service.Wait(request, time.Millisecond, time.Second)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when the timeout elapses", func(t *testing.T) {
+		driver.Reset()
+		for i := 0; i < 10; i++ {
+			stubRequest(driver, &Request{ID: "req1"})
+		}
+
+		_, err := service.Wait(request, 5*time.Millisecond, 12*time.Millisecond)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("without a request", func(t *testing.T) {
+		if _, err := service.Wait(nil, time.Millisecond, time.Second); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
 func stubRequests(driver *MockDriver, requests ...*Request) {
 	pages := make([][]byte, 0)
 
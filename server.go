@@ -87,26 +87,34 @@ func (service *ServerService) ForEnvironment(environment *Environment, params Pa
 }
 
 func (service *ServerService) collection(path string, params Params) []*Server {
+	servers, _ := service.fetch(path, params)
+
+	return servers
+}
+
+func (service *ServerService) fetch(path string, params Params) ([]*Server, error) {
 	servers := make([]*Server, 0)
 	response := service.Driver.Get(path, params)
 
-	if response.Okay() {
-		for _, page := range response.Pages {
-			wrapper := struct {
-				Servers []*Server `json:"servers,omitempty"`
-			}{}
-
-			if err := json.Unmarshal(page, &wrapper); err == nil {
-				servers = append(servers, wrapper.Servers...)
-			} else {
-				if debuggable.Enabled() {
-					fmt.Println("[DEBUG] Couldn't unmarshal the server data:", err)
-				}
+	if !response.Okay() {
+		return servers, response.Error
+	}
+
+	for _, page := range response.Pages {
+		wrapper := struct {
+			Servers []*Server `json:"servers,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(page, &wrapper); err == nil {
+			servers = append(servers, wrapper.Servers...)
+		} else {
+			if debuggable.Enabled() {
+				fmt.Println("[DEBUG] Couldn't unmarshal the server data:", err)
 			}
 		}
 	}
 
-	return servers
+	return servers, nil
 }
 
 /*
@@ -3,6 +3,8 @@ package eygo
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 )
 
 // Snapshot is a data structure that models a snapshot on the Engine Yard API.
@@ -30,6 +32,18 @@ type Snapshot struct {
 	} `json:"region,omitempty"`
 }
 
+// Complete returns true if the snapshot has finished processing, and false
+// otherwise.
+func (snapshot *Snapshot) Complete() bool {
+	return snapshot.Progress >= 100 && snapshot.State == "completed"
+}
+
+// Failed returns true if the upstream API reports that the snapshot could not
+// be taken, and false otherwise.
+func (snapshot *Snapshot) Failed() bool {
+	return snapshot.State == "error" || snapshot.State == "failed"
+}
+
 // SnapshotService is a repository that one can use to create, retrieve, delete,
 // and perform other operations on Snapshot records on the API.
 type SnapshotService struct {
@@ -79,6 +93,148 @@ func (service *SnapshotService) Find(id string) (*Snapshot, error) {
 	return nil, response.Error
 }
 
+// Create requests a new snapshot of the given Server's volumes. Snapshots are
+// taken asynchronously, so the Request that tracks the snapshot operation is
+// returned. If there are issues along the way, an error is returned.
+func (service *SnapshotService) Create(server *Server) (*Request, error) {
+	if server == nil || server.ID == 0 {
+		return nil, fmt.Errorf("No valid server given")
+	}
+
+	response := service.Driver.Post(
+		fmt.Sprintf("servers/%d/snapshots", server.ID),
+		Params{},
+		nil,
+	)
+
+	return service.request(response)
+}
+
+// Destroy deletes the given Snapshot from the upstream API. Snaplocked
+// snapshots can't be deleted. If there are issues along the way, an error is
+// returned.
+func (service *SnapshotService) Destroy(snapshot *Snapshot) error {
+	if snapshot == nil || snapshot.ID == 0 {
+		return fmt.Errorf("No valid snapshot given")
+	}
+
+	if snapshot.Snaplocked {
+		return fmt.Errorf("Snapshot %d is snaplocked", snapshot.ID)
+	}
+
+	response := service.Driver.Delete(
+		fmt.Sprintf("snapshots/%d", snapshot.ID),
+		Params{},
+	)
+
+	if !response.Okay() {
+		return response.Error
+	}
+
+	return nil
+}
+
+// Restore requests a new volume created from the given Snapshot and attached
+// to the given Server. The Request that tracks the restore operation is
+// returned. If there are issues along the way, an error is returned.
+func (service *SnapshotService) Restore(snapshot *Snapshot, server *Server) (*Request, error) {
+	if snapshot == nil || snapshot.ID == 0 {
+		return nil, fmt.Errorf("No valid snapshot given")
+	}
+
+	if server == nil || server.ID == 0 {
+		return nil, fmt.Errorf("No valid server given")
+	}
+
+	if !snapshot.Complete() {
+		return nil, fmt.Errorf("Snapshot %d is not complete", snapshot.ID)
+	}
+
+	wrapper := struct {
+		Volume struct {
+			SnapshotID int `json:"snapshot_id,omitempty"`
+			ServerID   int `json:"server_id,omitempty"`
+		} `json:"volume"`
+	}{}
+	wrapper.Volume.SnapshotID = snapshot.ID
+	wrapper.Volume.ServerID = server.ID
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	response := service.Driver.Post(
+		fmt.Sprintf("snapshots/%d/volumes", snapshot.ID),
+		nil,
+		body,
+	)
+
+	return service.request(response)
+}
+
+// WaitForCompletion polls the upstream API every interval until the given
+// Snapshot is complete, then returns the completed Snapshot. If the snapshot
+// fails, can't be retrieved, or isn't complete before the timeout elapses, an
+// error is returned. The interval must be positive.
+func (service *SnapshotService) WaitForCompletion(snapshot *Snapshot, interval time.Duration, timeout time.Duration) (*Snapshot, error) {
+	if snapshot == nil || snapshot.ID == 0 {
+		return nil, fmt.Errorf("No valid snapshot given")
+	}
+
+	if interval <= 0 {
+		return nil, fmt.Errorf("The polling interval must be positive")
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		current, err := service.Find(strconv.Itoa(snapshot.ID))
+		if err != nil {
+			return nil, err
+		}
+
+		if current == nil {
+			return nil, fmt.Errorf("Couldn't find snapshot %d", snapshot.ID)
+		}
+
+		if current.Failed() {
+			return current, fmt.Errorf("Snapshot %d failed", snapshot.ID)
+		}
+
+		if current.Complete() {
+			return current, nil
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return current, fmt.Errorf(
+				"Timed out waiting for snapshot %d (%d%% complete)",
+				snapshot.ID,
+				current.Progress,
+			)
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+func (service *SnapshotService) request(response Response) (*Request, error) {
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		Request *Request `json:"request,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Request, nil
+}
+
 func (service *SnapshotService) collection(path string, params Params) []*Snapshot {
 	snapshots := make([]*Snapshot, 0)
 	response := service.Driver.Get(path, params)
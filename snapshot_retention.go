@@ -0,0 +1,240 @@
+package eygo
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// RetentionPolicy is a data structure that describes which snapshots should
+// be kept for an environment. Any snapshot that is not kept by at least one
+// rule is eligible for deletion. Snaplocked snapshots are always kept.
+type RetentionPolicy struct {
+	// KeepLast is the number of most recent snapshots to keep.
+	KeepLast int
+
+	// Daily is the number of days for which the newest snapshot is kept.
+	Daily int
+
+	// Weekly is the number of weeks for which the newest snapshot is kept.
+	Weekly int
+
+	// Monthly is the number of months for which the newest snapshot is kept.
+	Monthly int
+}
+
+// Empty returns true if the policy would not keep any snapshots, and false
+// otherwise.
+func (policy RetentionPolicy) Empty() bool {
+	return policy.KeepLast <= 0 &&
+		policy.Daily <= 0 &&
+		policy.Weekly <= 0 &&
+		policy.Monthly <= 0
+}
+
+// RetentionDecision is a data structure that describes what a RetentionPlan
+// intends to do with a single Snapshot, and why.
+type RetentionDecision struct {
+	Snapshot *Snapshot
+	Keep     bool
+	Reasons  []string
+	Deleted  bool
+	Error    error
+}
+
+// RetentionPlan is a data structure that describes the result of applying a
+// RetentionPolicy to a set of snapshots.
+type RetentionPlan struct {
+	Policy    RetentionPolicy
+	Decisions []*RetentionDecision
+}
+
+// Keep returns the snapshots that the plan keeps.
+func (plan *RetentionPlan) Keep() []*Snapshot {
+	return plan.snapshots(true)
+}
+
+// Delete returns the snapshots that the plan deletes.
+func (plan *RetentionPlan) Delete() []*Snapshot {
+	return plan.snapshots(false)
+}
+
+func (plan *RetentionPlan) snapshots(keep bool) []*Snapshot {
+	snapshots := make([]*Snapshot, 0)
+
+	for _, decision := range plan.Decisions {
+		if decision.Keep == keep {
+			snapshots = append(snapshots, decision.Snapshot)
+		}
+	}
+
+	return snapshots
+}
+
+// PlanRetention takes an array of snapshots and a RetentionPolicy, returning
+// a RetentionPlan that describes which snapshots should be kept and which
+// should be deleted. The decisions in the plan are ordered from newest to
+// oldest snapshot. Nothing is deleted by this function.
+func PlanRetention(snapshots []*Snapshot, policy RetentionPolicy) *RetentionPlan {
+	plan := &RetentionPlan{Policy: policy, Decisions: make([]*RetentionDecision, 0)}
+
+	type dated struct {
+		decision  *RetentionDecision
+		createdAt time.Time
+	}
+
+	candidates := make([]*dated, 0)
+
+	for _, snapshot := range snapshots {
+		decision := &RetentionDecision{Snapshot: snapshot, Reasons: make([]string, 0)}
+		plan.Decisions = append(plan.Decisions, decision)
+
+		if snapshot.Snaplocked {
+			decision.keep("snaplocked")
+		}
+
+		createdAt, err := parseTimestamp(snapshot.CreatedAt)
+		if err != nil {
+			decision.keep("creation time unknown")
+			continue
+		}
+
+		if snapshot.Failed() {
+			decision.Reasons = append(decision.Reasons, "failed snapshot")
+			continue
+		}
+
+		if !snapshot.Complete() {
+			decision.keep("not yet complete")
+			continue
+		}
+
+		candidates = append(candidates, &dated{decision: decision, createdAt: createdAt})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].createdAt.After(candidates[j].createdAt)
+	})
+
+	buckets := []struct {
+		limit  int
+		name   string
+		bucket func(time.Time) string
+	}{
+		{policy.Daily, "day", func(t time.Time) string { return t.Format("2006-01-02") }},
+		{policy.Weekly, "week", func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{policy.Monthly, "month", func(t time.Time) string { return t.Format("2006-01") }},
+	}
+
+	for index, candidate := range candidates {
+		if index < policy.KeepLast {
+			candidate.decision.keep(fmt.Sprintf("one of the last %d snapshots", policy.KeepLast))
+		}
+	}
+
+	for _, rule := range buckets {
+		seen := make(map[string]bool)
+
+		for _, candidate := range candidates {
+			if len(seen) >= rule.limit {
+				break
+			}
+
+			key := rule.bucket(candidate.createdAt)
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+			candidate.decision.keep(fmt.Sprintf("newest snapshot for %s %s", rule.name, key))
+		}
+	}
+
+	for _, decision := range plan.Decisions {
+		if !decision.Keep && len(decision.Reasons) == 0 {
+			decision.Reasons = append(decision.Reasons, "not retained by policy")
+		}
+	}
+
+	sort.SliceStable(plan.Decisions, func(i, j int) bool {
+		left, _ := parseTimestamp(plan.Decisions[i].Snapshot.CreatedAt)
+		right, _ := parseTimestamp(plan.Decisions[j].Snapshot.CreatedAt)
+
+		return left.After(right)
+	})
+
+	return plan
+}
+
+func (decision *RetentionDecision) keep(reason string) {
+	decision.Keep = true
+	decision.Reasons = append(decision.Reasons, reason)
+}
+
+// PlanRetention retrieves the snapshots for the given Environment and returns
+// the RetentionPlan that results from applying the given RetentionPolicy to
+// them. Nothing is deleted by this method.
+func (service *SnapshotService) PlanRetention(environment *Environment, policy RetentionPolicy) (*RetentionPlan, error) {
+	if environment == nil || environment.ID == 0 {
+		return nil, fmt.Errorf("No valid environment given")
+	}
+
+	if policy.Empty() {
+		return nil, fmt.Errorf("The retention policy doesn't keep any snapshots")
+	}
+
+	return PlanRetention(service.ForEnvironment(environment, nil), policy), nil
+}
+
+// EnforceRetention plans the retention of the given Environment's snapshots
+// with the given RetentionPolicy. Unless dryRun is true, every snapshot that
+// the plan does not keep is then deleted through the API, and the outcome is
+// recorded in the plan's decisions. If any deletion fails, an error is
+// returned along with the plan.
+func (service *SnapshotService) EnforceRetention(environment *Environment, policy RetentionPolicy, dryRun bool) (*RetentionPlan, error) {
+	plan, err := service.PlanRetention(environment, policy)
+	if err != nil || dryRun {
+		return plan, err
+	}
+
+	failures := 0
+
+	for _, decision := range plan.Decisions {
+		if decision.Keep {
+			continue
+		}
+
+		decision.Error = service.Destroy(decision.Snapshot)
+		if decision.Error != nil {
+			failures = failures + 1
+			continue
+		}
+
+		decision.Deleted = true
+	}
+
+	if failures > 0 {
+		return plan, fmt.Errorf("Couldn't delete %d snapshots", failures)
+	}
+
+	return plan, nil
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
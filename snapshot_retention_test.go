@@ -0,0 +1,231 @@
+package eygo
+
+import (
+	"fmt"
+	"testing"
+)
+
+func retentionSnapshot(id int, createdAt string) *Snapshot {
+	return &Snapshot{ID: id, State: "completed", Progress: 100, CreatedAt: createdAt}
+}
+
+func retentionDecision(plan *RetentionPlan, id int) *RetentionDecision {
+	for _, decision := range plan.Decisions {
+		if decision.Snapshot.ID == id {
+			return decision
+		}
+	}
+
+	return nil
+}
+
+func TestPlanRetention(t *testing.T) {
+	snapshots := []*Snapshot{
+		retentionSnapshot(1, "2018-04-10T12:00:00Z"),
+		retentionSnapshot(2, "2018-05-20T12:00:00Z"),
+		retentionSnapshot(3, "2018-06-01T12:00:00Z"),
+		retentionSnapshot(4, "2018-06-14T01:00:00Z"),
+		retentionSnapshot(5, "2018-06-14T12:00:00Z"),
+		retentionSnapshot(6, "2018-06-15T12:00:00Z"),
+	}
+
+	t.Run("keeping the last N snapshots", func(t *testing.T) {
+		plan := PlanRetention(snapshots, RetentionPolicy{KeepLast: 2})
+
+		t.Run("it keeps the newest snapshots", func(t *testing.T) {
+			kept := plan.Keep()
+
+			if len(kept) != 2 || kept[0].ID != 6 || kept[1].ID != 5 {
+				t.Errorf("Expected snapshots 6 and 5 to be kept, got %v", kept)
+			}
+		})
+
+		t.Run("it deletes the rest with a reason", func(t *testing.T) {
+			deleted := plan.Delete()
+
+			if len(deleted) != 4 {
+				t.Errorf("Expected 4 snapshots to be deleted, got %d", len(deleted))
+			}
+
+			if reasons := retentionDecision(plan, 1).Reasons; len(reasons) != 1 || reasons[0] != "not retained by policy" {
+				t.Errorf("Unexpected reasons: %v", reasons)
+			}
+		})
+	})
+
+	t.Run("keeping daily snapshots", func(t *testing.T) {
+		plan := PlanRetention(snapshots, RetentionPolicy{Daily: 2})
+
+		t.Run("it keeps the newest snapshot for each day", func(t *testing.T) {
+			if !retentionDecision(plan, 6).Keep || !retentionDecision(plan, 5).Keep {
+				t.Errorf("Expected snapshots 6 and 5 to be kept")
+			}
+
+			if retentionDecision(plan, 4).Keep {
+				t.Errorf("Expected snapshot 4 to be deleted")
+			}
+		})
+
+		t.Run("it explains why a snapshot is kept", func(t *testing.T) {
+			reasons := retentionDecision(plan, 5).Reasons
+
+			if len(reasons) != 1 || reasons[0] != "newest snapshot for day 2018-06-14" {
+				t.Errorf("Unexpected reasons: %v", reasons)
+			}
+		})
+	})
+
+	t.Run("keeping weekly and monthly snapshots", func(t *testing.T) {
+		plan := PlanRetention(snapshots, RetentionPolicy{Weekly: 1, Monthly: 3})
+
+		t.Run("it keeps the newest snapshot for each bucket", func(t *testing.T) {
+			for _, id := range []int{6, 2, 1} {
+				if !retentionDecision(plan, id).Keep {
+					t.Errorf("Expected snapshot %d to be kept", id)
+				}
+			}
+
+			for _, id := range []int{3, 4, 5} {
+				if retentionDecision(plan, id).Keep {
+					t.Errorf("Expected snapshot %d to be deleted", id)
+				}
+			}
+		})
+
+		t.Run("it records every rule that keeps a snapshot", func(t *testing.T) {
+			if reasons := retentionDecision(plan, 6).Reasons; len(reasons) != 2 {
+				t.Errorf("Expected 2 reasons, got %v", reasons)
+			}
+		})
+	})
+
+	t.Run("with snapshots that must not be deleted", func(t *testing.T) {
+		protected := []*Snapshot{
+			{ID: 7, State: "completed", Progress: 100, Snaplocked: true, CreatedAt: "2017-01-01T00:00:00Z"},
+			{ID: 8, State: "pending", Progress: 20, CreatedAt: "2017-01-02T00:00:00Z"},
+			{ID: 9, State: "completed", Progress: 100},
+			{ID: 10, State: "error", CreatedAt: "2018-06-16T00:00:00Z"},
+			retentionSnapshot(11, "2018-06-15T00:00:00Z"),
+		}
+
+		plan := PlanRetention(protected, RetentionPolicy{KeepLast: 1})
+
+		t.Run("it keeps snaplocked snapshots", func(t *testing.T) {
+			if !retentionDecision(plan, 7).Keep {
+				t.Errorf("Expected the snaplocked snapshot to be kept")
+			}
+		})
+
+		t.Run("it keeps incomplete snapshots", func(t *testing.T) {
+			if !retentionDecision(plan, 8).Keep {
+				t.Errorf("Expected the incomplete snapshot to be kept")
+			}
+		})
+
+		t.Run("it keeps snapshots with an unknown creation time", func(t *testing.T) {
+			if !retentionDecision(plan, 9).Keep {
+				t.Errorf("Expected the undated snapshot to be kept")
+			}
+		})
+
+		t.Run("it doesn't count failed snapshots", func(t *testing.T) {
+			if retentionDecision(plan, 10).Keep {
+				t.Errorf("Expected the failed snapshot to be deleted")
+			}
+
+			if !retentionDecision(plan, 11).Keep {
+				t.Errorf("Expected the newest completed snapshot to be kept")
+			}
+		})
+	})
+}
+
+func TestSnapshotService_EnforceRetention(t *testing.T) {
+	environment := &Environment{ID: 1, Name: "Environment 1"}
+	driver := NewMockDriver()
+	service := NewSnapshotService(driver)
+	policy := RetentionPolicy{KeepLast: 1}
+
+	stub := func() {
+		driver.Reset()
+		stubEnvironmentSnapshots(
+			driver,
+			environment,
+			retentionSnapshot(1, "2018-06-13T12:00:00Z"),
+			retentionSnapshot(2, "2018-06-14T12:00:00Z"),
+			retentionSnapshot(3, "2018-06-15T12:00:00Z"),
+		)
+	}
+
+	t.Run("with an empty policy", func(t *testing.T) {
+		stub()
+
+		_, err := service.EnforceRetention(environment, RetentionPolicy{}, false)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it deletes nothing", func(t *testing.T) {
+			if len(driver.Requests("delete")) != 0 {
+				t.Errorf("Expected no delete requests")
+			}
+		})
+	})
+
+	t.Run("as a dry run", func(t *testing.T) {
+		stub()
+
+		plan, err := service.EnforceRetention(environment, policy, true)
+
+		t.Run("it returns the plan", func(t *testing.T) {
+			if plan == nil || len(plan.Delete()) != 2 {
+				t.Errorf("Expected a plan that deletes 2 snapshots")
+			}
+		})
+
+		t.Run("it deletes nothing", func(t *testing.T) {
+			if len(driver.Requests("delete")) != 0 {
+				t.Errorf("Expected no delete requests")
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when executed", func(t *testing.T) {
+		stub()
+		driver.AddResponse("delete", "snapshots/1", Response{Pages: [][]byte{[]byte(`true`)}})
+		driver.AddResponse("delete", "snapshots/2", Response{Error: fmt.Errorf("Oh no!")})
+
+		plan, err := service.EnforceRetention(environment, policy, false)
+
+		t.Run("it deletes the snapshots that aren't kept", func(t *testing.T) {
+			if deletes := driver.Requests("delete"); len(deletes) != 2 {
+				t.Errorf("Expected 2 delete requests, got %v", deletes)
+			}
+		})
+
+		t.Run("it records the outcome of each deletion", func(t *testing.T) {
+			if !retentionDecision(plan, 1).Deleted {
+				t.Errorf("Expected snapshot 1 to be deleted")
+			}
+
+			if decision := retentionDecision(plan, 2); decision.Deleted || decision.Error == nil {
+				t.Errorf("Expected snapshot 2 to record its failure")
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
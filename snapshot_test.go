@@ -2,9 +2,10 @@ package eygo
 
 import (
 	"encoding/json"
-	//"fmt"
+	"fmt"
 	"strconv"
 	"testing"
+	"time"
 )
 
 func TestNewSnapshotService(t *testing.T) {
@@ -163,6 +164,271 @@ func TestSnapshotService_Find(t *testing.T) {
 	})
 }
 
+func TestSnapshotService_Create(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewSnapshotService(driver)
+	server := &Server{ID: 1, Role: "db_master"}
+
+	t.Run("with an invalid server", func(t *testing.T) {
+		_, err := service.Create(&Server{})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when the snapshot is requested", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse(
+			"post",
+			"servers/1/snapshots",
+			Response{
+				Pages: [][]byte{
+					[]byte(`{"request": {"id": "req1", "type": "snapshot_server"}}`),
+				},
+			},
+		)
+
+		result, err := service.Create(server)
+
+		t.Run("it returns the snapshot request", func(t *testing.T) {
+			if result == nil || result.ID != "req1" {
+				t.Errorf("Expected request req1, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when the request fails", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("post", "servers/1/snapshots", Response{Error: fmt.Errorf("Oh no!")})
+
+		result, err := service.Create(server)
+
+		t.Run("it returns no request", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no request, got %s", result.ID)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestSnapshotService_Destroy(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewSnapshotService(driver)
+
+	t.Run("with a snaplocked snapshot", func(t *testing.T) {
+		driver.Reset()
+
+		err := service.Destroy(&Snapshot{ID: 1, Snaplocked: true})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it makes no API call", func(t *testing.T) {
+			if len(driver.Requests("delete")) != 0 {
+				t.Errorf("Expected no delete requests")
+			}
+		})
+	})
+
+	t.Run("when successful", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "snapshots/1", Response{Pages: [][]byte{[]byte(`true`)}})
+
+		err := service.Destroy(&Snapshot{ID: 1})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when unsuccessful", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "snapshots/1", Response{Error: fmt.Errorf("Oh no!")})
+
+		err := service.Destroy(&Snapshot{ID: 1})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestSnapshotService_Restore(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewSnapshotService(driver)
+	server := &Server{ID: 2}
+	snapshot := &Snapshot{ID: 1, State: "completed", Progress: 100}
+
+	t.Run("with an incomplete snapshot", func(t *testing.T) {
+		_, err := service.Restore(&Snapshot{ID: 1, State: "pending", Progress: 40}, server)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("with an invalid server", func(t *testing.T) {
+		_, err := service.Restore(snapshot, nil)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when the restore is requested", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse(
+			"post",
+			"snapshots/1/volumes",
+			Response{
+				Pages: [][]byte{
+					[]byte(`{"request": {"id": "req2", "type": "create_volume"}}`),
+				},
+			},
+		)
+
+		result, err := service.Restore(snapshot, server)
+
+		t.Run("it returns the restore request", func(t *testing.T) {
+			if result == nil || result.ID != "req2" {
+				t.Errorf("Expected request req2, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestSnapshotService_WaitForCompletion(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewSnapshotService(driver)
+	snapshot := &Snapshot{ID: 1}
+
+	t.Run("with a non-positive interval", func(t *testing.T) {
+		driver.Reset()
+
+		if _, err := service.WaitForCompletion(snapshot, 0, time.Second); err == nil {
+			t.Errorf("Expected an error")
+		}
+
+		if len(driver.Requests("get")) != 0 {
+			t.Errorf("Expected no get requests")
+		}
+	})
+
+	t.Run("when the snapshot is missing from the response", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("get", "snapshots/1", Response{Pages: [][]byte{[]byte(`{}`)}})
+
+		if _, err := service.WaitForCompletion(snapshot, time.Millisecond, time.Second); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when the snapshot completes", func(t *testing.T) {
+		driver.Reset()
+		stubSnapshot(driver, &Snapshot{ID: 1, State: "pending", Progress: 10})
+		stubSnapshot(driver, &Snapshot{ID: 1, State: "pending", Progress: 100})
+		stubSnapshot(driver, &Snapshot{ID: 1, State: "completed", Progress: 100})
+
+		result, err := service.WaitForCompletion(snapshot, time.Millisecond, time.Second)
+
+		t.Run("it returns the completed snapshot", func(t *testing.T) {
+			if result == nil || !result.Complete() {
+				t.Errorf("Expected a completed snapshot, got %v", result)
+			}
+		})
+
+		t.Run("it polls until completion", func(t *testing.T) {
+			if polls := len(driver.Requests("get")); polls != 3 {
+				t.Errorf("Expected 3 polls, got %d", polls)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("when the snapshot fails", func(t *testing.T) {
+		driver.Reset()
+		stubSnapshot(driver, &Snapshot{ID: 1, State: "error", Progress: 30})
+
+		_, err := service.WaitForCompletion(snapshot, time.Millisecond, time.Second)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when the timeout elapses", func(t *testing.T) {
+		driver.Reset()
+		for i := 0; i < 10; i++ {
+			stubSnapshot(driver, &Snapshot{ID: 1, State: "pending", Progress: 50})
+		}
+
+		result, err := service.WaitForCompletion(snapshot, 5*time.Millisecond, 12*time.Millisecond)
+
+		t.Run("it returns the latest snapshot", func(t *testing.T) {
+			if result == nil || result.Progress != 50 {
+				t.Errorf("Expected the pending snapshot, got %v", result)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when the snapshot can't be retrieved", func(t *testing.T) {
+		driver.Reset()
+
+		_, err := service.WaitForCompletion(snapshot, time.Millisecond, time.Second)
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
 func stubSnapshots(driver *MockDriver, snapshots ...*Snapshot) {
 	pages := make([][]byte, 0)
 
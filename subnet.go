@@ -2,6 +2,7 @@ package eygo
 
 import (
 	"encoding/json"
+	"fmt"
 )
 
 // Subnet is a data structure that models a subnet on the Engine Yard API
@@ -16,6 +17,48 @@ type Subnet struct {
 	Primary       bool   `json:"primary,omitempty"`
 }
 
+// Validate checks that the Subnet has a valid CIDR and a location, that it
+// lies within the given Network's CIDR, and that it doesn't overlap any of
+// the given existing subnets.
+func (subnet *Subnet) Validate(network *Network, existing []*Subnet) error {
+	block, err := ParseCIDR(subnet.CIDR)
+	if err != nil {
+		return err
+	}
+
+	if len(subnet.Location) == 0 {
+		return fmt.Errorf("A subnet requires a location")
+	}
+
+	if network != nil {
+		parent, err := ParseCIDR(network.CIDR)
+		if err != nil {
+			return fmt.Errorf("Network %s: %s", network.ID, err)
+		}
+
+		if !cidrContains(parent, block) {
+			return fmt.Errorf("Subnet %s is not within network %s", block, parent)
+		}
+	}
+
+	for _, other := range existing {
+		if len(subnet.ID) > 0 && other.ID == subnet.ID {
+			continue
+		}
+
+		otherBlock, err := ParseCIDR(other.CIDR)
+		if err != nil {
+			return fmt.Errorf("Existing subnet %s: %s", other.ID, err)
+		}
+
+		if cidrOverlaps(block, otherBlock) {
+			return fmt.Errorf("Subnet %s overlaps existing subnet %s (%s)", block, other.ID, otherBlock)
+		}
+	}
+
+	return nil
+}
+
 // SubnetService is a repository that one can use to create, retrieve, delete,
 // and perform other operations on Subnet records on the API.
 type SubnetService struct {
@@ -41,40 +84,108 @@ func (service *SubnetService) ForNetwork(network *Network, params Params) []*Sub
 // Find returns the Subnet record identified by the given subnet id. If there
 // are errors in retrieving this information, an error is returned as well.
 func (service *SubnetService) Find(id string) (*Subnet, error) {
-	response := service.Driver.Get("subnets/"+id, nil)
-	if response.Okay() {
-		wrapper := struct {
-			Subnet *Subnet `json:"subnet,omitempty"`
-		}{}
+	return service.unwrap(service.Driver.Get("subnets/"+id, nil))
+}
 
-		err := json.Unmarshal(response.Pages[0], &wrapper)
-		if err != nil {
-			return nil, err
-		}
+// Create takes a Network and a Subnet, saving the Subnet on the upstream API
+// within the given Network. The Subnet is validated against the Network and
+// its existing subnets before anything is sent. If there are issues along the
+// way, an error is returned. Otherwise, the newly created Subnet is returned.
+func (service *SubnetService) Create(network *Network, subnet *Subnet) (*Subnet, error) {
+	if network == nil || len(network.ID) == 0 {
+		return nil, fmt.Errorf("No valid network given")
+	}
+
+	if subnet == nil {
+		return nil, fmt.Errorf("No subnet given")
+	}
 
-		return wrapper.Subnet, nil
+	existing, err := service.fetch("networks/"+network.ID+"/subnets", nil)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, response.Error
+	if err := subnet.Validate(network, existing); err != nil {
+		return nil, err
+	}
+
+	wrapper := struct {
+		Subnet *Subnet `json:"subnet,omitempty"`
+	}{
+		Subnet: &Subnet{
+			CIDR:     subnet.CIDR,
+			Location: subnet.Location,
+			Primary:  subnet.Primary,
+		},
+	}
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Post("networks/"+network.ID+"/subnets", nil, body),
+	)
+}
+
+// Destroy deletes the given Subnet from the upstream API. If there are
+// issues along the way, an error is returned.
+func (service *SubnetService) Destroy(subnet *Subnet) error {
+	if subnet == nil || len(subnet.ID) == 0 {
+		return fmt.Errorf("No valid subnet given")
+	}
+
+	response := service.Driver.Delete("subnets/"+subnet.ID, Params{})
+	if !response.Okay() {
+		return response.Error
+	}
+
+	return nil
+}
+
+func (service *SubnetService) unwrap(response Response) (*Subnet, error) {
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	wrapper := struct {
+		Subnet *Subnet `json:"subnet,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response.Pages[0], &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Subnet, nil
 }
 
 func (service *SubnetService) collection(path string, params Params) []*Subnet {
+	subnets, _ := service.fetch(path, params)
+
+	return subnets
+}
+
+func (service *SubnetService) fetch(path string, params Params) ([]*Subnet, error) {
 	subnets := make([]*Subnet, 0)
 	response := service.Driver.Get(path, params)
 
-	if response.Okay() {
-		for _, page := range response.Pages {
-			wrapper := struct {
-				Subnets []*Subnet `json:"subnets,omitempty"`
-			}{}
+	if !response.Okay() {
+		return subnets, response.Error
+	}
 
-			if err := json.Unmarshal(page, &wrapper); err == nil {
-				subnets = append(subnets, wrapper.Subnets...)
-			}
+	for _, page := range response.Pages {
+		wrapper := struct {
+			Subnets []*Subnet `json:"subnets,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(page, &wrapper); err == nil {
+			subnets = append(subnets, wrapper.Subnets...)
 		}
 	}
 
-	return subnets
+	return subnets, nil
 }
 
 /*
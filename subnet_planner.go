@@ -0,0 +1,291 @@
+package eygo
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+)
+
+// SubnetPlanner is a data structure that knows the address space of a Network
+// and the subnets already carved from it, and that can find room for more.
+type SubnetPlanner struct {
+	Network *Network
+	Subnets []*Subnet
+
+	block  *net.IPNet
+	bits   int
+	ranges []addressRange
+}
+
+type addressRange struct {
+	first *big.Int
+	last  *big.Int
+}
+
+// NewSubnetPlanner returns a SubnetPlanner for the given Network and its
+// existing subnets. Subnets that don't overlap the Network are ignored, and
+// a subnet that contains the whole Network uses all of its address space. If
+// the Network's CIDR or any subnet's CIDR is invalid, an error is returned.
+func NewSubnetPlanner(network *Network, subnets []*Subnet) (*SubnetPlanner, error) {
+	if network == nil {
+		return nil, fmt.Errorf("No network given")
+	}
+
+	block, err := ParseCIDR(network.CIDR)
+	if err != nil {
+		return nil, err
+	}
+
+	_, bits := block.Mask.Size()
+
+	planner := &SubnetPlanner{
+		Network: network,
+		Subnets: subnets,
+		block:   block,
+		bits:    bits,
+		ranges:  make([]addressRange, 0),
+	}
+
+	for _, subnet := range subnets {
+		used, err := ParseCIDR(subnet.CIDR)
+		if err != nil {
+			return nil, fmt.Errorf("Existing subnet %s: %s", subnet.ID, err)
+		}
+
+		if !cidrOverlaps(block, used) {
+			continue
+		}
+
+		if !cidrContains(block, used) {
+			used = block
+		}
+
+		planner.ranges = append(planner.ranges, planner.rangeOf(used))
+	}
+
+	sort.Slice(planner.ranges, func(i, j int) bool {
+		return planner.ranges[i].first.Cmp(planner.ranges[j].first) < 0
+	})
+
+	return planner, nil
+}
+
+// Free returns the unused address space of the Network as the smallest list
+// of CIDR blocks that covers it, in address order.
+func (planner *SubnetPlanner) Free() []string {
+	free := make([]string, 0)
+
+	for _, gap := range planner.gaps() {
+		first := new(big.Int).Set(gap.first)
+
+		for first.Cmp(gap.last) <= 0 {
+			size := planner.bits - planner.prefix()
+
+			for size > 0 {
+				if planner.aligned(first, size) && planner.lastOf(first, size).Cmp(gap.last) <= 0 {
+					break
+				}
+
+				size--
+			}
+
+			free = append(free, planner.cidr(first, planner.bits-size))
+			first.Add(planner.lastOf(first, size), big.NewInt(1))
+		}
+	}
+
+	return free
+}
+
+// Propose returns the next count unused subnets with the given prefix length,
+// in address order, assigning them to the given locations in turn. The
+// proposed subnets are not created. If the Network doesn't have room for
+// them, an error is returned.
+func (planner *SubnetPlanner) Propose(count int, prefix int, locations []string) ([]*Subnet, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("At least one subnet must be proposed")
+	}
+
+	if len(locations) == 0 {
+		return nil, fmt.Errorf("At least one location is required")
+	}
+
+	if prefix < planner.prefix() || prefix > planner.bits {
+		return nil, fmt.Errorf(
+			"Prefix /%d doesn't fit within network %s",
+			prefix,
+			planner.block,
+		)
+	}
+
+	size := planner.bits - prefix
+	step := new(big.Int).Lsh(big.NewInt(1), uint(size))
+	proposed := make([]*Subnet, 0, count)
+
+	for _, gap := range planner.gaps() {
+		first := planner.alignUp(gap.first, size)
+
+		for len(proposed) < count && planner.lastOf(first, size).Cmp(gap.last) <= 0 {
+			proposed = append(proposed, &Subnet{
+				CIDR:     planner.cidr(first, prefix),
+				Location: locations[len(proposed)%len(locations)],
+			})
+
+			first = new(big.Int).Add(first, step)
+		}
+	}
+
+	if len(proposed) < count {
+		return nil, fmt.Errorf(
+			"Network %s only has room for %d more /%d subnets",
+			planner.block,
+			len(proposed),
+			prefix,
+		)
+	}
+
+	return proposed, nil
+}
+
+func (planner *SubnetPlanner) prefix() int {
+	ones, _ := planner.block.Mask.Size()
+
+	return ones
+}
+
+func (planner *SubnetPlanner) gaps() []addressRange {
+	whole := planner.rangeOf(planner.block)
+	gaps := make([]addressRange, 0)
+	next := new(big.Int).Set(whole.first)
+
+	for _, used := range planner.ranges {
+		if used.first.Cmp(next) > 0 {
+			gaps = append(gaps, addressRange{
+				first: next,
+				last:  new(big.Int).Sub(used.first, big.NewInt(1)),
+			})
+		}
+
+		if after := new(big.Int).Add(used.last, big.NewInt(1)); after.Cmp(next) > 0 {
+			next = after
+		}
+	}
+
+	if next.Cmp(whole.last) <= 0 {
+		gaps = append(gaps, addressRange{first: next, last: whole.last})
+	}
+
+	return gaps
+}
+
+func (planner *SubnetPlanner) rangeOf(block *net.IPNet) addressRange {
+	ones, _ := block.Mask.Size()
+	first := planner.toInt(block.IP)
+
+	return addressRange{first: first, last: planner.lastOf(first, planner.bits-ones)}
+}
+
+func (planner *SubnetPlanner) lastOf(first *big.Int, size int) *big.Int {
+	span := new(big.Int).Lsh(big.NewInt(1), uint(size))
+
+	return span.Add(span, first).Sub(span, big.NewInt(1))
+}
+
+func (planner *SubnetPlanner) aligned(address *big.Int, size int) bool {
+	mask := new(big.Int).Lsh(big.NewInt(1), uint(size))
+	mask.Sub(mask, big.NewInt(1))
+
+	return new(big.Int).And(address, mask).Sign() == 0
+}
+
+func (planner *SubnetPlanner) alignUp(address *big.Int, size int) *big.Int {
+	if planner.aligned(address, size) {
+		return new(big.Int).Set(address)
+	}
+
+	aligned := new(big.Int).Rsh(address, uint(size))
+	aligned.Add(aligned, big.NewInt(1))
+
+	return aligned.Lsh(aligned, uint(size))
+}
+
+func (planner *SubnetPlanner) toInt(ip net.IP) *big.Int {
+	if planner.bits == 32 {
+		ip = ip.To4()
+	} else {
+		ip = ip.To16()
+	}
+
+	return new(big.Int).SetBytes(ip)
+}
+
+func (planner *SubnetPlanner) cidr(address *big.Int, prefix int) string {
+	raw := address.Bytes()
+	ip := make(net.IP, planner.bits/8)
+	copy(ip[len(ip)-len(raw):], raw)
+
+	block := &net.IPNet{IP: ip, Mask: net.CIDRMask(prefix, planner.bits)}
+
+	return block.String()
+}
+
+// Plan returns a SubnetPlanner for the given Network and the subnets that it
+// currently has on the upstream API.
+func (service *SubnetService) Plan(network *Network) (*SubnetPlanner, error) {
+	if network == nil || len(network.ID) == 0 {
+		return nil, fmt.Errorf("No valid network given")
+	}
+
+	subnets, err := service.fetch("networks/"+network.ID+"/subnets", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSubnetPlanner(network, subnets)
+}
+
+// CreatePlanned proposes count subnets with the given prefix length for the
+// given Network, spread across the given locations, and creates them on the
+// upstream API in order. If there are issues along the way, the subnets that
+// were created before the issue are returned along with an error.
+func (service *SubnetService) CreatePlanned(network *Network, count int, prefix int, locations []string) ([]*Subnet, error) {
+	planner, err := service.Plan(network)
+	if err != nil {
+		return nil, err
+	}
+
+	proposed, err := planner.Propose(count, prefix, locations)
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]*Subnet, 0, len(proposed))
+
+	for _, subnet := range proposed {
+		result, err := service.Create(network, subnet)
+		if err != nil {
+			return created, fmt.Errorf("Couldn't create subnet %s: %s", subnet.CIDR, err)
+		}
+
+		created = append(created, result)
+	}
+
+	return created, nil
+}
+
+/*
+Copyright 2018 Dennis Walters
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
@@ -0,0 +1,271 @@
+package eygo
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestNewSubnetPlanner(t *testing.T) {
+	t.Run("without a network", func(t *testing.T) {
+		if _, err := NewSubnetPlanner(nil, nil); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with an invalid network CIDR", func(t *testing.T) {
+		if _, err := NewSubnetPlanner(&Network{CIDR: "10.0.0.1/16"}, nil); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with an existing subnet that can't be parsed", func(t *testing.T) {
+		subnets := []*Subnet{{ID: "sub-1", CIDR: "10.0.0.1/24"}}
+
+		if _, err := NewSubnetPlanner(&Network{CIDR: "10.0.0.0/16"}, subnets); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestSubnetService_Plan(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewSubnetService(driver)
+	network := &Network{ID: "net-1", CIDR: "10.0.0.0/16"}
+
+	t.Run("when the existing subnets can't be retrieved", func(t *testing.T) {
+		driver.AddResponse("get", "networks/net-1/subnets", Response{Error: fmt.Errorf("Oh no!")})
+
+		if _, err := service.Plan(network); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestSubnetPlanner_Free(t *testing.T) {
+	network := &Network{ID: "net-1", CIDR: "10.0.0.0/16"}
+
+	t.Run("for an empty network", func(t *testing.T) {
+		planner, _ := NewSubnetPlanner(network, nil)
+
+		t.Run("it is the whole network", func(t *testing.T) {
+			if free := planner.Free(); !reflect.DeepEqual(free, []string{"10.0.0.0/16"}) {
+				t.Errorf("Unexpected free space: %v", free)
+			}
+		})
+	})
+
+	t.Run("for a network with subnets", func(t *testing.T) {
+		planner, _ := NewSubnetPlanner(network, []*Subnet{
+			{CIDR: "10.0.1.0/24"},
+			{CIDR: "10.0.0.0/24"},
+			{CIDR: "10.0.4.0/22"},
+			{CIDR: "10.1.0.0/24"},
+		})
+
+		expected := []string{
+			"10.0.2.0/23",
+			"10.0.8.0/21",
+			"10.0.16.0/20",
+			"10.0.32.0/19",
+			"10.0.64.0/18",
+			"10.0.128.0/17",
+		}
+
+		t.Run("it is the gaps between subnets", func(t *testing.T) {
+			if free := planner.Free(); !reflect.DeepEqual(free, expected) {
+				t.Errorf("Expected %v, got %v", expected, free)
+			}
+		})
+	})
+
+	t.Run("for a full network", func(t *testing.T) {
+		planner, _ := NewSubnetPlanner(network, []*Subnet{{CIDR: "10.0.0.0/16"}})
+
+		t.Run("it is empty", func(t *testing.T) {
+			if free := planner.Free(); len(free) != 0 {
+				t.Errorf("Expected no free space, got %v", free)
+			}
+		})
+	})
+
+	t.Run("for an IPv6 network", func(t *testing.T) {
+		planner, _ := NewSubnetPlanner(
+			&Network{CIDR: "fd00::/62"},
+			[]*Subnet{{CIDR: "fd00::/64"}},
+		)
+
+		expected := []string{"fd00:0:0:1::/64", "fd00:0:0:2::/63"}
+
+		t.Run("it is the gaps between subnets", func(t *testing.T) {
+			if free := planner.Free(); !reflect.DeepEqual(free, expected) {
+				t.Errorf("Expected %v, got %v", expected, free)
+			}
+		})
+	})
+
+	t.Run("when a subnet contains the whole network", func(t *testing.T) {
+		planner, _ := NewSubnetPlanner(
+			&Network{CIDR: "10.0.0.0/16"},
+			[]*Subnet{{CIDR: "10.0.0.0/8"}},
+		)
+
+		t.Run("it is empty", func(t *testing.T) {
+			if free := planner.Free(); len(free) != 0 {
+				t.Errorf("Expected no free space, got %v", free)
+			}
+		})
+
+		t.Run("it has no room for proposals", func(t *testing.T) {
+			if _, err := planner.Propose(1, 24, []string{"us-east-1a"}); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestSubnetPlanner_Propose(t *testing.T) {
+	network := &Network{ID: "net-1", CIDR: "10.0.0.0/16"}
+	planner, _ := NewSubnetPlanner(network, []*Subnet{
+		{CIDR: "10.0.0.0/24"},
+		{CIDR: "10.0.2.0/24"},
+	})
+	locations := []string{"us-east-1a", "us-east-1b"}
+
+	t.Run("it fills gaps in address order", func(t *testing.T) {
+		proposed, err := planner.Propose(3, 24, locations)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		cidrs := make([]string, 0)
+		for _, subnet := range proposed {
+			cidrs = append(cidrs, subnet.CIDR)
+		}
+
+		expected := []string{"10.0.1.0/24", "10.0.3.0/24", "10.0.4.0/24"}
+		if !reflect.DeepEqual(cidrs, expected) {
+			t.Errorf("Expected %v, got %v", expected, cidrs)
+		}
+	})
+
+	t.Run("it spreads subnets across locations", func(t *testing.T) {
+		proposed, _ := planner.Propose(3, 24, locations)
+
+		for i, subnet := range proposed {
+			if subnet.Location != locations[i%2] {
+				t.Errorf("Expected subnet %d in %s, got %s", i, locations[i%2], subnet.Location)
+			}
+		}
+	})
+
+	t.Run("it aligns larger subnets", func(t *testing.T) {
+		proposed, _ := planner.Propose(1, 22, locations)
+
+		if len(proposed) != 1 || proposed[0].CIDR != "10.0.4.0/22" {
+			t.Errorf("Expected 10.0.4.0/22, got %v", proposed)
+		}
+	})
+
+	t.Run("it rejects prefixes that don't fit the network", func(t *testing.T) {
+		for _, prefix := range []int{8, 33} {
+			if _, err := planner.Propose(1, prefix, locations); err == nil {
+				t.Errorf("Expected /%d to be rejected", prefix)
+			}
+		}
+	})
+
+	t.Run("it requires a location", func(t *testing.T) {
+		if _, err := planner.Propose(1, 24, nil); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("it reports when there isn't enough room", func(t *testing.T) {
+		if _, err := planner.Propose(2, 17, locations); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestSubnetService_CreatePlanned(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewSubnetService(driver)
+	network := &Network{ID: "net-1", CIDR: "10.0.0.0/16"}
+	existing := &Subnet{ID: "sub-1", CIDR: "10.0.0.0/24"}
+
+	t.Run("when every subnet can be created", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			stubNetworkSubnets(driver, network, existing)
+		}
+
+		for i := 2; i <= 3; i++ {
+			driver.AddResponse(
+				"post",
+				"networks/net-1/subnets",
+				Response{Pages: [][]byte{[]byte(fmt.Sprintf(`{"subnet": {"id": "sub-%d"}}`, i))}},
+			)
+		}
+
+		created, err := service.CreatePlanned(network, 2, 24, []string{"us-east-1a", "us-east-1b"})
+
+		t.Run("it creates the proposed subnets", func(t *testing.T) {
+			bodies := driver.Bodies("post")
+			expected := []string{
+				`{"subnet":{"cidr":"10.0.1.0/24","location":"us-east-1a"}}`,
+				`{"subnet":{"cidr":"10.0.2.0/24","location":"us-east-1b"}}`,
+			}
+
+			if len(bodies) != len(expected) {
+				t.Fatalf("Expected %d requests, got %d", len(expected), len(bodies))
+			}
+
+			for i := range expected {
+				if string(bodies[i]) != expected[i] {
+					t.Errorf("Expected %s, got %s", expected[i], bodies[i])
+				}
+			}
+		})
+
+		t.Run("it returns the created subnets", func(t *testing.T) {
+			if len(created) != 2 || created[1].ID != "sub-3" {
+				t.Errorf("Unexpected subnets: %v", created)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+	})
+
+	t.Run("when a creation fails", func(t *testing.T) {
+		driver.Reset()
+
+		for i := 0; i < 3; i++ {
+			stubNetworkSubnets(driver, network, existing)
+		}
+
+		driver.AddResponse(
+			"post",
+			"networks/net-1/subnets",
+			Response{Pages: [][]byte{[]byte(`{"subnet": {"id": "sub-2"}}`)}},
+		)
+		driver.AddResponse("post", "networks/net-1/subnets", Response{Error: fmt.Errorf("Oh no!")})
+
+		created, err := service.CreatePlanned(network, 2, 24, []string{"us-east-1a"})
+
+		t.Run("it returns the subnets created so far", func(t *testing.T) {
+			if len(created) != 1 || created[0].ID != "sub-2" {
+				t.Errorf("Unexpected subnets: %v", created)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
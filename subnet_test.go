@@ -2,6 +2,7 @@ package eygo
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 )
 
@@ -160,6 +161,152 @@ func TestSubnetService_ForNetwork(t *testing.T) {
 
 }
 
+func TestSubnet_Validate(t *testing.T) {
+	network := &Network{ID: "net-1", CIDR: "10.0.0.0/16"}
+	existing := []*Subnet{
+		{ID: "sub-1", CIDR: "10.0.0.0/24"},
+		{ID: "sub-2", CIDR: "10.0.1.0/24"},
+	}
+
+	t.Run("it accepts a free block within the network", func(t *testing.T) {
+		subnet := &Subnet{CIDR: "10.0.2.0/24", Location: "us-east-1a"}
+
+		if err := subnet.Validate(network, existing); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("it doesn't treat a subnet as overlapping itself", func(t *testing.T) {
+		subnet := &Subnet{ID: "sub-1", CIDR: "10.0.0.0/24", Location: "us-east-1a"}
+
+		if err := subnet.Validate(network, existing); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("it rejects existing subnets that can't be parsed", func(t *testing.T) {
+		subnet := &Subnet{CIDR: "10.0.2.0/24", Location: "us-east-1a"}
+		broken := append([]*Subnet{{ID: "sub-3", CIDR: "10.0.2.1/24"}}, existing...)
+
+		if err := subnet.Validate(network, broken); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	invalid := map[string]*Subnet{
+		"unparseable":             {CIDR: "10.0.2.0/99", Location: "us-east-1a"},
+		"missing a location":      {CIDR: "10.0.2.0/24"},
+		"outside the network":     {CIDR: "10.1.0.0/24", Location: "us-east-1a"},
+		"larger than the network": {CIDR: "10.0.0.0/8", Location: "us-east-1a"},
+		"overlapping a subnet":    {CIDR: "10.0.0.128/25", Location: "us-east-1a"},
+		"covering subnets":        {CIDR: "10.0.0.0/23", Location: "us-east-1a"},
+	}
+
+	for name, subnet := range invalid {
+		t.Run("it rejects a subnet "+name, func(t *testing.T) {
+			if err := subnet.Validate(network, existing); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestSubnetService_Create(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewSubnetService(driver)
+	network := &Network{ID: "net-1", CIDR: "10.0.0.0/16"}
+
+	t.Run("when the subnet overlaps an existing subnet", func(t *testing.T) {
+		stubNetworkSubnets(driver, network, &Subnet{ID: "sub-1", CIDR: "10.0.0.0/24"})
+
+		_, err := service.Create(network, &Subnet{CIDR: "10.0.0.0/25", Location: "us-east-1a"})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it doesn't contact the API", func(t *testing.T) {
+			if len(driver.Requests("post")) != 0 {
+				t.Errorf("Expected no post requests")
+			}
+		})
+	})
+
+	t.Run("when the existing subnets can't be retrieved", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("get", "networks/net-1/subnets", Response{Error: fmt.Errorf("Oh no!")})
+
+		_, err := service.Create(network, &Subnet{CIDR: "10.0.0.0/25", Location: "us-east-1a"})
+
+		t.Run("it returns an error without creating the subnet", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+
+			if len(driver.Requests("post")) != 0 {
+				t.Errorf("Expected no post requests")
+			}
+		})
+	})
+
+	t.Run("when the subnet is free", func(t *testing.T) {
+		driver.Reset()
+		stubNetworkSubnets(driver, network, &Subnet{ID: "sub-1", CIDR: "10.0.0.0/24"})
+		driver.AddResponse(
+			"post",
+			"networks/net-1/subnets",
+			Response{Pages: [][]byte{[]byte(`{"subnet": {"id": "sub-2", "cidr": "10.0.1.0/24"}}`)}},
+		)
+
+		result, err := service.Create(network, &Subnet{CIDR: "10.0.1.0/24", Location: "us-east-1a", Primary: true})
+
+		t.Run("it sends the subnet settings", func(t *testing.T) {
+			bodies := driver.Bodies("post")
+			expected := `{"subnet":{"cidr":"10.0.1.0/24","location":"us-east-1a","primary":true}}`
+
+			if len(bodies) != 1 || string(bodies[0]) != expected {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns the new subnet", func(t *testing.T) {
+			if result == nil || result.ID != "sub-2" {
+				t.Errorf("Expected subnet sub-2, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestSubnetService_Destroy(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewSubnetService(driver)
+
+	t.Run("when the API accepts the deletion", func(t *testing.T) {
+		driver.AddResponse("delete", "subnets/sub-1", Response{})
+
+		if err := service.Destroy(&Subnet{ID: "sub-1"}); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("when the API rejects the deletion", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "subnets/sub-1", Response{Error: fmt.Errorf("Oh no!")})
+
+		if err := service.Destroy(&Subnet{ID: "sub-1"}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
 func stubSubnets(driver *MockDriver, subnets ...*Subnet) {
 	pages := make([][]byte, 0)
 
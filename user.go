@@ -2,6 +2,8 @@ package eygo
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/mail"
 )
 
 // User is a data strcture that models a user on the Engine Yard API.
@@ -18,6 +20,37 @@ type User struct {
 	UpdatedAt string `json:"updated_at,omitempty"`
 }
 
+const (
+	// OwnerRole is the account role that has full control of an Account.
+	OwnerRole = "owner"
+
+	// AdminRole is the account role that can manage an Account's resources
+	// and members.
+	AdminRole = "admin"
+
+	// MemberRole is the account role that can use an Account's resources.
+	MemberRole = "member"
+)
+
+// Invitation is a data structure that models an invitation for someone to
+// join an Account on the Engine Yard API.
+type Invitation struct {
+	ID         string `json:"id,omitempty"`
+	Email      string `json:"email,omitempty"`
+	Role       string `json:"role,omitempty"`
+	AccountURL string `json:"account,omitempty"`
+	InviterURL string `json:"inviter,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	AcceptedAt string `json:"accepted_at,omitempty"`
+	DeletedAt  string `json:"deleted_at,omitempty"`
+}
+
+// Pending returns true if the invitation has been neither accepted nor
+// withdrawn, and false otherwise.
+func (invitation *Invitation) Pending() bool {
+	return len(invitation.AcceptedAt) == 0 && len(invitation.DeletedAt) == 0
+}
+
 // UserService is a repository one can use to retrieve User records from
 // the API.
 type UserService struct {
@@ -31,28 +64,144 @@ func NewUserService(driver Driver) *UserService {
 
 // All returns an array of all User records that match the provided Params.
 func (service *UserService) All(params Params) []*User {
-	users := make([]*User, 0)
-	response := service.Driver.Get("users", params)
+	return service.collection("users", params)
+}
+
+// ForAccount returns an array of Users that are both associated with the
+// given Account and matching the given Params.
+func (service *UserService) ForAccount(account *Account, params Params) []*User {
+	return service.collection("accounts/"+account.ID+"/users", params)
+}
+
+// Find returns the User record identified by the given user id. If there are
+// errors in retrieving this information, an error is returned as well.
+func (service *UserService) Find(id string) (*User, error) {
+	return service.unwrap(service.Driver.Get("users/"+id, nil))
+}
+
+// Invite invites the owner of the given email address to join the given
+// Account with the given role. If there are issues along the way, an error is
+// returned. Otherwise, the newly created Invitation is returned.
+func (service *UserService) Invite(account *Account, email string, role string) (*Invitation, error) {
+	if account == nil || len(account.ID) == 0 {
+		return nil, fmt.Errorf("No valid account given")
+	}
+
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, fmt.Errorf("Invalid email address %q", email)
+	}
+
+	if len(role) == 0 {
+		return nil, fmt.Errorf("An invitation requires a role")
+	}
+
+	wrapper := struct {
+		Invitation *Invitation `json:"invitation,omitempty"`
+	}{Invitation: &Invitation{Email: email, Role: role}}
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	response := service.Driver.Post("accounts/"+account.ID+"/invitations", nil, body)
+	if !response.Okay() {
+		return nil, response.Error
+	}
+
+	created := struct {
+		Invitation *Invitation `json:"invitation,omitempty"`
+	}{}
+
+	if err := json.Unmarshal(response.Pages[0], &created); err != nil {
+		return nil, err
+	}
+
+	return created.Invitation, nil
+}
+
+// PendingInvitations returns an array of the Invitations to the given
+// Account that match the given Params and have been neither accepted nor
+// withdrawn.
+func (service *UserService) PendingInvitations(account *Account, params Params) []*Invitation {
+	pending := make([]*Invitation, 0)
+	response := service.Driver.Get("accounts/"+account.ID+"/invitations", params)
 
 	if response.Okay() {
 		for _, page := range response.Pages {
 			wrapper := struct {
-				Users []*User `json:"users,omitempty"`
+				Invitations []*Invitation `json:"invitations,omitempty"`
 			}{}
 
 			if err := json.Unmarshal(page, &wrapper); err == nil {
-				users = append(users, wrapper.Users...)
+				for _, invitation := range wrapper.Invitations {
+					if invitation.Pending() {
+						pending = append(pending, invitation)
+					}
+				}
 			}
 		}
 	}
 
-	return users
+	return pending
+}
+
+// ChangeRole changes the role of the given User within the given Account. If
+// there are issues along the way, an error is returned. Otherwise, the
+// updated User is returned.
+func (service *UserService) ChangeRole(account *Account, user *User, role string) (*User, error) {
+	if account == nil || len(account.ID) == 0 {
+		return nil, fmt.Errorf("No valid account given")
+	}
+
+	if user == nil || len(user.ID) == 0 {
+		return nil, fmt.Errorf("No valid user given")
+	}
+
+	if len(role) == 0 {
+		return nil, fmt.Errorf("No role given")
+	}
+
+	wrapper := struct {
+		User *User `json:"user,omitempty"`
+	}{User: &User{Role: role}}
+
+	body, err := json.Marshal(&wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.unwrap(
+		service.Driver.Put("accounts/"+account.ID+"/users/"+user.ID, nil, body),
+	)
+}
+
+// Remove removes the given User from the given Account. The User itself is
+// not deleted. If there are issues along the way, an error is returned.
+func (service *UserService) Remove(account *Account, user *User) error {
+	if account == nil || len(account.ID) == 0 {
+		return fmt.Errorf("No valid account given")
+	}
+
+	if user == nil || len(user.ID) == 0 {
+		return fmt.Errorf("No valid user given")
+	}
+
+	response := service.Driver.Delete("accounts/"+account.ID+"/users/"+user.ID, Params{})
+	if !response.Okay() {
+		return response.Error
+	}
+
+	return nil
 }
 
 // Current returns the user that is associated with the current API session.
 // If there are issues along the way, an error is returned.
 func (service *UserService) Current() (*User, error) {
-	response := service.Driver.Get("users/current", nil)
+	return service.unwrap(service.Driver.Get("users/current", nil))
+}
+
+func (service *UserService) unwrap(response Response) (*User, error) {
 	if !response.Okay() {
 		return nil, response.Error
 	}
@@ -69,6 +218,33 @@ func (service *UserService) Current() (*User, error) {
 	return wrapper.User, nil
 }
 
+func (service *UserService) collection(path string, params Params) []*User {
+	users, _ := service.fetch(path, params)
+
+	return users
+}
+
+func (service *UserService) fetch(path string, params Params) ([]*User, error) {
+	users := make([]*User, 0)
+	response := service.Driver.Get(path, params)
+
+	if !response.Okay() {
+		return users, response.Error
+	}
+
+	for _, page := range response.Pages {
+		wrapper := struct {
+			Users []*User `json:"users,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(page, &wrapper); err == nil {
+			users = append(users, wrapper.Users...)
+		}
+	}
+
+	return users, nil
+}
+
 /*
 Copyright 2018 Dennis Walters
 
@@ -2,7 +2,7 @@ package eygo
 
 import (
 	"encoding/json"
-	//"fmt"
+	"fmt"
 	"testing"
 )
 
@@ -109,6 +109,220 @@ func TestUserService_Current(t *testing.T) {
 	})
 }
 
+func TestUserService_ForAccount(t *testing.T) {
+	account := &Account{ID: "1"}
+	driver := NewMockDriver()
+	service := NewUserService(driver)
+
+	t.Run("when there are matching users", func(t *testing.T) {
+		stubAccountUsers(driver, account, &User{ID: "1"}, &User{ID: "2"})
+
+		if all := service.ForAccount(account, nil); len(all) != 2 {
+			t.Errorf("Expected 2 users, got %d", len(all))
+		}
+	})
+
+	t.Run("when there are no matching users", func(t *testing.T) {
+		driver.Reset()
+
+		if all := service.ForAccount(account, nil); len(all) != 0 {
+			t.Errorf("Expected 0 users, got %d", len(all))
+		}
+	})
+}
+
+func TestUserService_Find(t *testing.T) {
+	driver := NewMockDriver()
+	service := NewUserService(driver)
+	stubUser(driver, &User{ID: "1", Name: "User 1"})
+
+	t.Run("for a known user", func(t *testing.T) {
+		result, err := service.Find("1")
+
+		t.Run("it is the requested user", func(t *testing.T) {
+			if result == nil || result.ID != "1" {
+				t.Errorf("Expected user 1, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+
+	t.Run("for an unknown user", func(t *testing.T) {
+		result, err := service.Find("2")
+
+		t.Run("it returns no user", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no user, got user %s", result.ID)
+			}
+		})
+
+		t.Run("it returns an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestUserService_Invite(t *testing.T) {
+	account := &Account{ID: "1"}
+	driver := NewMockDriver()
+	service := NewUserService(driver)
+
+	t.Run("with an invalid email address", func(t *testing.T) {
+		if _, err := service.Invite(account, "not an address", MemberRole); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("without a role", func(t *testing.T) {
+		if _, err := service.Invite(account, "bob@example.com", ""); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with a valid invitation", func(t *testing.T) {
+		driver.AddResponse(
+			"post",
+			"accounts/1/invitations",
+			Response{Pages: [][]byte{[]byte(`{"invitation": {"id": "inv-1", "email": "bob@example.com", "role": "admin"}}`)}},
+		)
+
+		result, err := service.Invite(account, "bob@example.com", AdminRole)
+
+		t.Run("it sends the email address and role", func(t *testing.T) {
+			bodies := driver.Bodies("post")
+			expected := `{"invitation":{"email":"bob@example.com","role":"admin"}}`
+
+			if len(bodies) != 1 || string(bodies[0]) != expected {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns the invitation", func(t *testing.T) {
+			if result == nil || result.ID != "inv-1" {
+				t.Errorf("Expected invitation inv-1, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestUserService_PendingInvitations(t *testing.T) {
+	account := &Account{ID: "1"}
+	driver := NewMockDriver()
+	service := NewUserService(driver)
+
+	t.Run("when there are invitations", func(t *testing.T) {
+		stubAccountInvitations(
+			driver,
+			account,
+			&Invitation{ID: "inv-1"},
+			&Invitation{ID: "inv-2", AcceptedAt: "2018-06-01T00:00:00Z"},
+			&Invitation{ID: "inv-3", DeletedAt: "2018-06-02T00:00:00Z"},
+			&Invitation{ID: "inv-4"},
+		)
+
+		pending := service.PendingInvitations(account, nil)
+
+		t.Run("it contains only the pending invitations", func(t *testing.T) {
+			if len(pending) != 2 || pending[0].ID != "inv-1" || pending[1].ID != "inv-4" {
+				t.Errorf("Unexpected invitations: %v", pending)
+			}
+		})
+	})
+
+	t.Run("when there are no invitations", func(t *testing.T) {
+		driver.Reset()
+
+		if pending := service.PendingInvitations(account, nil); len(pending) != 0 {
+			t.Errorf("Expected 0 invitations, got %d", len(pending))
+		}
+	})
+}
+
+func TestUserService_ChangeRole(t *testing.T) {
+	account := &Account{ID: "1"}
+	user := &User{ID: "2"}
+	driver := NewMockDriver()
+	service := NewUserService(driver)
+
+	t.Run("without a role", func(t *testing.T) {
+		if _, err := service.ChangeRole(account, user, ""); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("with a role", func(t *testing.T) {
+		driver.AddResponse(
+			"put",
+			"accounts/1/users/2",
+			Response{Pages: [][]byte{[]byte(`{"user": {"id": "2", "role": "owner"}}`)}},
+		)
+
+		result, err := service.ChangeRole(account, user, OwnerRole)
+
+		t.Run("it sends the new role", func(t *testing.T) {
+			bodies := driver.Bodies("put")
+
+			if len(bodies) != 1 || string(bodies[0]) != `{"user":{"role":"owner"}}` {
+				t.Errorf("Unexpected request bodies: %s", bodies)
+			}
+		})
+
+		t.Run("it returns the updated user", func(t *testing.T) {
+			if result == nil || result.Role != OwnerRole {
+				t.Errorf("Expected the updated user, got %v", result)
+			}
+		})
+
+		t.Run("it returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error")
+			}
+		})
+	})
+}
+
+func TestUserService_Remove(t *testing.T) {
+	account := &Account{ID: "1"}
+	driver := NewMockDriver()
+	service := NewUserService(driver)
+
+	t.Run("without a user", func(t *testing.T) {
+		if err := service.Remove(account, &User{}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+
+	t.Run("when the API accepts the removal", func(t *testing.T) {
+		driver.AddResponse("delete", "accounts/1/users/2", Response{})
+
+		if err := service.Remove(account, &User{ID: "2"}); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("when the API rejects the removal", func(t *testing.T) {
+		driver.Reset()
+		driver.AddResponse("delete", "accounts/1/users/2", Response{Error: fmt.Errorf("Oh no!")})
+
+		if err := service.Remove(account, &User{ID: "2"}); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
 func stubUsers(driver *MockDriver, users ...*User) {
 	pages := make([][]byte, 0)
 
@@ -134,3 +348,42 @@ func stubCurrent(driver *MockDriver, current *User) {
 		driver.AddResponse("get", "users/current", Response{Pages: pages})
 	}
 }
+
+func stubAccountUsers(driver *MockDriver, account *Account, users ...*User) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		Users []*User `json:"users,omitempty"`
+	}{Users: users}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "accounts/"+account.ID+"/users", Response{Pages: pages})
+	}
+}
+
+func stubUser(driver *MockDriver, user *User) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		User *User `json:"user,omitempty"`
+	}{User: user}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "users/"+user.ID, Response{Pages: pages})
+	}
+}
+
+func stubAccountInvitations(driver *MockDriver, account *Account, invitations ...*Invitation) {
+	pages := make([][]byte, 0)
+
+	wrapper := struct {
+		Invitations []*Invitation `json:"invitations,omitempty"`
+	}{Invitations: invitations}
+
+	if encoded, err := json.Marshal(&wrapper); err == nil {
+		pages = append(pages, encoded)
+		driver.AddResponse("get", "accounts/"+account.ID+"/invitations", Response{Pages: pages})
+	}
+}